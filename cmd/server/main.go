@@ -1,13 +1,21 @@
 package main
 
 import (
-	"github.com/mjcode-max/TurboGin/internal/wire"
+	"flag"
 	"log"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/wire"
 )
 
 func main() {
+	// Command line flags have the highest precedence:
+	// defaults < config file < config.<env>.yaml < env vars (.env) < flags
+	opts := config.RegisterFlags(flag.CommandLine)
+	flag.Parse()
+
 	// Initialize the application using Wire dependency injection
-	server, cleanup, err := wire.InitApp()
+	app, cleanup, err := wire.InitApp(*opts)
 	if err != nil {
 
 		// Since logger might not be initialized, we'll use zap's global logger
@@ -16,8 +24,9 @@ func main() {
 	// Ensure cleanup runs when the application exits
 	defer cleanup()
 
-	// Start the server
-	if err := server.Run(); err != nil {
+	// Start all components and block until shutdown
+	if err := app.Run(); err != nil {
+		cleanup()
 		log.Fatal(err)
 	}
 }
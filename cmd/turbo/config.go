@@ -0,0 +1,257 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/mjcode-max/TurboGin/config"
+)
+
+func configCmd(args []string) {
+	if len(args) < 1 {
+		log.Fatal("Usage: turbo config <validate|print|docs|env> ...")
+	}
+	switch args[0] {
+	case "validate":
+		validateConfig(args[1:])
+	case "print":
+		printConfig(args[1:])
+	case "docs":
+		configDocs(args[1:])
+	case "env":
+		printEnvVars()
+	default:
+		log.Fatalf("Unknown config command: %s", args[0])
+	}
+}
+
+// loadConfig 与服务端相同的方式加载配置（--config/--env/--env-file/--set）
+func loadConfig(fs *flag.FlagSet, args []string) (*config.Config, error) {
+	opts := config.RegisterFlags(fs)
+	_ = fs.Parse(args)
+	return config.Load(*opts)
+}
+
+// validateConfig 加载并校验配置，列出全部未通过校验的配置项
+func validateConfig(args []string) {
+	cfg, err := loadConfig(flag.NewFlagSet("config validate", flag.ExitOnError), args)
+	if err != nil {
+		fmt.Println("Configuration is invalid:")
+		var verr config.ValidationError
+		if errors.As(err, &verr) {
+			for _, fe := range verr {
+				fmt.Printf("  - %s\n", fe.Error())
+			}
+		} else {
+			fmt.Printf("  - %v\n", err)
+		}
+		os.Exit(1)
+	}
+	fmt.Printf("Configuration is valid (env: %s, files: %s)\n", cfg.Env, filesString(cfg.Files))
+}
+
+// printConfig 输出合并后的生效配置，secret 字段已脱敏
+func printConfig(args []string) {
+	fs := flag.NewFlagSet("config print", flag.ExitOnError)
+	format := fs.String("format", "yaml", "output format: yaml or json")
+	cfg, err := loadConfig(fs, args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	switch *format {
+	case "yaml":
+		out, err := yaml.Marshal(cfg)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Printf("# env: %s, files: %s\n%s", cfg.Env, filesString(cfg.Files), out)
+	case "json":
+		out, err := json.MarshalIndent(cfg, "", "  ")
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(string(out))
+	default:
+		log.Fatalf("Unknown format: %s", *format)
+	}
+}
+
+func filesString(files []string) string {
+	if len(files) == 0 {
+		return "none"
+	}
+	return strings.Join(files, ", ")
+}
+
+// configDocs 根据结构体标签（comment、validate、secret）和默认值生成配置参考文档
+func configDocs(args []string) {
+	fs := flag.NewFlagSet("config docs", flag.ExitOnError)
+	format := fs.String("format", "md", "output format: md or yaml")
+	output := fs.String("output", "", "output file (default stdout)")
+	_ = fs.Parse(args)
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Create %s failed: %v", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	fields := config.Fields()
+	switch *format {
+	case "md":
+		writeMarkdownDocs(w, fields)
+	case "yaml":
+		writeYAMLDocs(w, fields)
+	default:
+		log.Fatalf("Unknown format: %s", *format)
+	}
+	if *output != "" {
+		fmt.Printf("Generated %s\n", *output)
+	}
+}
+
+func writeMarkdownDocs(w io.Writer, fields []config.Field) {
+	fmt.Fprintln(w, "# 配置参考")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "由 `turbo config docs` 生成。优先级：默认值 < 配置文件 < config.<ENV>.yaml < 环境变量 < 命令行参数。")
+
+	section := ""
+	for _, f := range fields {
+		top, _, nested := strings.Cut(f.Key, ".")
+		if !nested {
+			top = "基础"
+		}
+		if top != section {
+			section = top
+			fmt.Fprintf(w, "\n## %s\n\n", section)
+			fmt.Fprintln(w, "| 配置项 | 环境变量 | 类型 | 默认值 | 校验规则 | 说明 |")
+			fmt.Fprintln(w, "|---|---|---|---|---|---|")
+		}
+		comment := f.Comment
+		if f.Secret {
+			comment = strings.TrimSpace("(secret) " + comment)
+		}
+		fmt.Fprintf(w, "| `%s` | %s | %s | %s | %s | %s |\n",
+			f.Key, mdCode(f.Env), f.Type, mdCode(f.Default), mdCode(f.Rules), mdEscape(comment))
+	}
+}
+
+func mdCode(s string) string {
+	if s == "" {
+		return ""
+	}
+	return "`" + mdEscape(s) + "`"
+}
+
+func mdEscape(s string) string {
+	return strings.ReplaceAll(s, "|", `\|`)
+}
+
+// writeYAMLDocs 输出带注释的完整配置模板，值为默认值
+func writeYAMLDocs(w io.Writer, fields []config.Field) {
+	fmt.Fprintln(w, "# 配置参考，由 turbo config docs --format yaml 生成，值为默认值")
+	var prev []string
+	for _, f := range fields {
+		parts := strings.Split(f.Key, ".")
+		parents := parts[:len(parts)-1]
+
+		common := 0
+		for common < len(prev) && common < len(parents) && prev[common] == parents[common] {
+			common++
+		}
+		for i := common; i < len(parents); i++ {
+			if i == 0 {
+				fmt.Fprintln(w)
+			}
+			fmt.Fprintf(w, "%s%s%s:\n", yamlComment(parents[:i+1]), strings.Repeat("  ", i), parents[i])
+		}
+		prev = parents
+
+		var notes []string
+		if f.Comment != "" {
+			notes = append(notes, f.Comment)
+		}
+		if f.Rules != "" {
+			notes = append(notes, "校验: "+f.Rules)
+		}
+		if f.Secret {
+			notes = append(notes, "secret, 支持file://、env://、vault://引用")
+		}
+		if f.Env != "" {
+			notes = append(notes, "环境变量: "+f.Env)
+		}
+		fmt.Fprintf(w, "%s%s%s: %s", yamlComment(parts), strings.Repeat("  ", len(parents)), parts[len(parts)-1], yamlValue(f))
+		if len(notes) > 0 {
+			fmt.Fprintf(w, "  # %s", strings.Join(notes, "; "))
+		}
+		fmt.Fprintln(w)
+	}
+}
+
+// yamlComment 结构体映射（<name> 占位）中的项以注释形式给出示例
+func yamlComment(parts []string) string {
+	for _, p := range parts {
+		if p == "<name>" {
+			return "# "
+		}
+	}
+	return ""
+}
+
+// yamlValue 以 YAML 写法表示默认值
+func yamlValue(f config.Field) string {
+	switch {
+	case strings.HasPrefix(f.Type, "[]"):
+		if f.Default == "" {
+			return "[]"
+		}
+		items := strings.Split(f.Default, ",")
+		for i, item := range items {
+			items[i] = strconv.Quote(item)
+		}
+		return "[" + strings.Join(items, ", ") + "]"
+	case strings.HasPrefix(f.Type, "map["):
+		return "{}"
+	case f.Type == "string":
+		return strconv.Quote(f.Default)
+	case f.Default == "":
+		if f.Type == "bool" {
+			return "false"
+		}
+		return "0"
+	}
+	return f.Default
+}
+
+// printEnvVars 列出所有可识别的环境变量，列表用逗号分隔，映射写作 k1=v1,k2=v2
+func printEnvVars() {
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "NAME\tTYPE\tDEFAULT\tDESCRIPTION")
+	for _, f := range config.Fields() {
+		if f.Env == "" {
+			continue
+		}
+		def, comment := f.Default, f.Comment
+		if f.Secret {
+			def = ""
+			comment = strings.TrimSpace("(secret) " + comment)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\n", f.Env, f.Type, def, comment)
+	}
+	w.Flush()
+}
@@ -0,0 +1,578 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"log"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+const (
+	ServiceDir = "./internal/service"
+	ProtoDir   = "./api/proto"
+	GRPCSvcDir = "./internal/grpcsvc"
+)
+
+// protoScalars Go基础类型到proto类型的映射，其余类型统一使用google.protobuf.Value(JSON)传输
+var protoScalars = map[string]string{
+	"string":  "string",
+	"bool":    "bool",
+	"int":     "int64",
+	"int8":    "int32",
+	"int16":   "int32",
+	"int32":   "int32",
+	"int64":   "int64",
+	"uint":    "uint64",
+	"uint8":   "uint32",
+	"uint16":  "uint32",
+	"uint32":  "uint32",
+	"uint64":  "uint64",
+	"float32": "float",
+	"float64": "double",
+	"[]byte":  "bytes",
+}
+
+// protoGoTypes proto类型在生成代码中对应的Go类型
+var protoGoTypes = map[string]string{
+	"string": "string",
+	"bool":   "bool",
+	"int32":  "int32",
+	"int64":  "int64",
+	"uint32": "uint32",
+	"uint64": "uint64",
+	"float":  "float32",
+	"double": "float64",
+	"bytes":  "[]byte",
+}
+
+// reservedVars 适配器方法中已占用的标识符
+var reservedVars = map[string]bool{"s": true, "ctx": true, "req": true, "resp": true, "err": true}
+
+type grpcField struct {
+	Name      string // proto字段名(snake_case)
+	GoName    string // 生成的Go字段名
+	Var       string // 适配器中的局部变量
+	GoType    string // service接口中的类型
+	ProtoType string
+	Number    int
+}
+
+func (f grpcField) IsValue() bool {
+	return f.ProtoType == "google.protobuf.Value"
+}
+
+func (f grpcField) ProtoGoType() string {
+	return protoGoTypes[f.ProtoType]
+}
+
+type grpcMethod struct {
+	Name       string
+	HasContext bool
+	HasError   bool
+	Params     []grpcField
+	Results    []grpcField
+}
+
+type grpcService struct {
+	Name       string // 如 User
+	Snake      string // 如 user
+	Module     string
+	Interface  string // 如 IUserService
+	Imports    []string
+	Methods    []grpcMethod
+	NeedsValue bool
+}
+
+// genGRPC 根据internal/service中的I<Name>Service接口生成proto定义和gRPC适配器
+func genGRPC(args []string) {
+	if len(args) < 1 {
+		log.Fatal("Usage: turbo gen grpc <Name>  (e.g. turbo gen grpc User)")
+	}
+
+	svc, err := parseService(args[0])
+	if err != nil {
+		log.Fatalf("Parse service failed: %v", err)
+	}
+
+	protoFile := filepath.Join(ProtoDir, svc.Snake, svc.Snake+".proto")
+	serverFile := filepath.Join(GRPCSvcDir, svc.Snake+"_server.go")
+	convertFile := filepath.Join(GRPCSvcDir, "convert.go")
+
+	writeTemplate(protoFile, protoTemplate, svc, false)
+	writeTemplate(serverFile, serverTemplate, svc, true)
+	if _, err := os.Stat(convertFile); os.IsNotExist(err) {
+		writeTemplate(convertFile, convertTemplate, svc, true)
+	}
+
+	compileProto(protoFile)
+
+	fmt.Printf("\nRegister the service in the module's RegisterGRPC (internal/modules/%s.go):\n", svc.Snake)
+	fmt.Printf("  %spb.Register%sServiceServer(s, grpcsvc.New%sServer(m.%sService))\n", svc.Snake, svc.Name, svc.Name, lowerFirst(svc.Name))
+}
+
+// parseService 解析服务接口的方法签名
+func parseService(name string) (*grpcService, error) {
+	name = upperFirst(name)
+	svc := &grpcService{
+		Name:      name,
+		Snake:     toSnake(name),
+		Module:    modulePath(),
+		Interface: "I" + name + "Service",
+	}
+
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, ServiceDir, nil, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, pkg := range pkgs {
+		for _, file := range pkg.Files {
+			for _, decl := range file.Decls {
+				gen, ok := decl.(*ast.GenDecl)
+				if !ok || gen.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gen.Specs {
+					ts := spec.(*ast.TypeSpec)
+					iface, ok := ts.Type.(*ast.InterfaceType)
+					if !ok || ts.Name.Name != svc.Interface {
+						continue
+					}
+					used := map[string]bool{}
+					for _, m := range iface.Methods.List {
+						fn, ok := m.Type.(*ast.FuncType)
+						if !ok || len(m.Names) == 0 {
+							continue
+						}
+						svc.Methods = append(svc.Methods, parseMethod(m.Names[0].Name, fn, used, svc))
+					}
+					svc.Imports = fileImports(file, used)
+					return svc, nil
+				}
+			}
+		}
+	}
+	return nil, fmt.Errorf("interface %s not found in %s", svc.Interface, ServiceDir)
+}
+
+// parseMethod 将方法签名转换为请求/响应字段
+func parseMethod(name string, fn *ast.FuncType, used map[string]bool, svc *grpcService) grpcMethod {
+	m := grpcMethod{Name: name}
+
+	idx := 0
+	for _, p := range fn.Params.List {
+		goType := typeString(p.Type, used)
+		if goType == "context.Context" {
+			m.HasContext = true
+			continue
+		}
+		names := p.Names
+		if len(names) == 0 {
+			names = []*ast.Ident{ast.NewIdent(fieldName(p.Type, idx))}
+		}
+		for _, n := range names {
+			idx++
+			m.Params = append(m.Params, newField(n.Name, goType, idx, svc))
+		}
+	}
+
+	if fn.Results != nil {
+		idx = 0
+		// 结果类型由调用推导，无需导入
+		for _, r := range fn.Results.List {
+			goType := typeString(r.Type, map[string]bool{})
+			if goType == "error" {
+				m.HasError = true
+				continue
+			}
+			names := r.Names
+			if len(names) == 0 {
+				names = []*ast.Ident{ast.NewIdent(fieldName(r.Type, idx))}
+			}
+			for _, n := range names {
+				idx++
+				m.Results = append(m.Results, newField(n.Name, goType, idx, svc))
+			}
+		}
+	}
+	// 适配器自身总会导入context
+	delete(used, "context")
+	return m
+}
+
+// Call 生成调用service方法的语句
+func (m grpcMethod) Call() string {
+	args := make([]string, 0, len(m.Params)+1)
+	if m.HasContext {
+		args = append(args, "ctx")
+	}
+	for _, p := range m.Params {
+		args = append(args, p.Var)
+	}
+	call := "s.svc." + m.Name + "(" + strings.Join(args, ", ") + ")"
+
+	lhs := make([]string, 0, len(m.Results)+1)
+	for i := range m.Results {
+		lhs = append(lhs, "r"+strconv.Itoa(i))
+	}
+	if m.HasError {
+		lhs = append(lhs, "err")
+	}
+	if len(lhs) == 0 {
+		return call
+	}
+	return strings.Join(lhs, ", ") + " := " + call
+}
+
+func newField(name, goType string, number int, svc *grpcService) grpcField {
+	protoType, ok := protoScalars[goType]
+	if !ok {
+		protoType = "google.protobuf.Value"
+		svc.NeedsValue = true
+	}
+	snake := toSnake(name)
+	v := lowerFirst(protoGoName(snake))
+	if reservedVars[v] || token.IsKeyword(v) {
+		v += "Arg"
+	}
+	return grpcField{
+		Name:      snake,
+		GoName:    protoGoName(snake),
+		Var:       v,
+		GoType:    goType,
+		ProtoType: protoType,
+		Number:    number,
+	}
+}
+
+// typeString 还原类型表达式，service包内的类型加上包名前缀
+func typeString(expr ast.Expr, used map[string]bool) string {
+	switch t := expr.(type) {
+	case *ast.Ident:
+		if _, ok := protoScalars[t.Name]; ok || t.Name == "error" || t.Name == "any" || t.Name == "byte" || t.Name == "rune" {
+			return t.Name
+		}
+		return "service." + t.Name
+	case *ast.SelectorExpr:
+		if x, ok := t.X.(*ast.Ident); ok {
+			used[x.Name] = true
+			return x.Name + "." + t.Sel.Name
+		}
+	case *ast.StarExpr:
+		return "*" + typeString(t.X, used)
+	case *ast.ArrayType:
+		return "[]" + typeString(t.Elt, used)
+	case *ast.MapType:
+		return "map[" + typeString(t.Key, used) + "]" + typeString(t.Value, used)
+	case *ast.InterfaceType:
+		return "interface{}"
+	}
+	return "interface{}"
+}
+
+// fieldName 为匿名参数根据类型推导字段名
+func fieldName(expr ast.Expr, idx int) string {
+	switch t := expr.(type) {
+	case *ast.StarExpr:
+		return fieldName(t.X, idx)
+	case *ast.SelectorExpr:
+		return lowerFirst(t.Sel.Name)
+	case *ast.Ident:
+		if _, ok := protoScalars[t.Name]; !ok {
+			return lowerFirst(t.Name)
+		}
+	case *ast.ArrayType:
+		return fieldName(t.Elt, idx) + "List"
+	}
+	return "value" + strconv.Itoa(idx+1)
+}
+
+// fileImports 保留方法签名中引用到的导入
+func fileImports(file *ast.File, used map[string]bool) []string {
+	var imports []string
+	for _, imp := range file.Imports {
+		path, _ := strconv.Unquote(imp.Path.Value)
+		name := filepath.Base(path)
+		if imp.Name != nil {
+			name = imp.Name.Name
+		}
+		if !used[name] {
+			continue
+		}
+		if imp.Name != nil {
+			imports = append(imports, imp.Name.Name+" "+imp.Path.Value)
+		} else {
+			imports = append(imports, imp.Path.Value)
+		}
+	}
+	return imports
+}
+
+// writeTemplate 渲染模板并写入文件，已存在的文件不会被覆盖
+func writeTemplate(path, tmpl string, data interface{}, gofmt bool) {
+	if _, err := os.Stat(path); err == nil {
+		fmt.Printf("Skip %s (already exists)\n", path)
+		return
+	}
+	renderFile(path, tmpl, data, gofmt)
+}
+
+// renderFile 渲染模板并写入文件，已存在时直接覆盖
+func renderFile(path, tmpl string, data interface{}, gofmt bool) {
+	var buf bytes.Buffer
+	t := template.Must(template.New(filepath.Base(path)).Funcs(template.FuncMap{
+		"lower": lowerFirst,
+	}).Parse(tmpl))
+	if err := t.Execute(&buf, data); err != nil {
+		log.Fatalf("Render %s failed: %v", path, err)
+	}
+
+	out := buf.Bytes()
+	if gofmt {
+		formatted, err := format.Source(out)
+		if err != nil {
+			log.Fatalf("Format %s failed: %v", path, err)
+		}
+		out = formatted
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		log.Fatalf("Create directory failed: %v", err)
+	}
+	if err := os.WriteFile(path, out, 0o644); err != nil {
+		log.Fatalf("Write %s failed: %v", path, err)
+	}
+	fmt.Printf("Generated %s\n", path)
+}
+
+// compileProto 调用protoc生成Go代码，未安装时给出提示
+func compileProto(protoFile string) {
+	if _, err := exec.LookPath("protoc"); err != nil {
+		fmt.Println("\nprotoc not found, generate Go code manually:")
+		fmt.Printf("  protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative %s\n", protoFile)
+		return
+	}
+	runCommand("protoc",
+		"--go_out=.", "--go_opt=paths=source_relative",
+		"--go-grpc_out=.", "--go-grpc_opt=paths=source_relative",
+		protoFile)
+}
+
+// modulePath 读取go.mod中的模块路径
+func modulePath() string {
+	f, err := os.Open("go.mod")
+	if err != nil {
+		log.Fatalf("Read go.mod failed (run turbo in the project root): %v", err)
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "module ") {
+			return strings.TrimSpace(strings.TrimPrefix(line, "module "))
+		}
+	}
+	log.Fatal("module path not found in go.mod")
+	return ""
+}
+
+func toSnake(s string) string {
+	var b strings.Builder
+	runes := []rune(s)
+	for i, r := range runes {
+		if unicode.IsUpper(r) {
+			if i > 0 && (unicode.IsLower(runes[i-1]) || (i+1 < len(runes) && unicode.IsLower(runes[i+1]))) {
+				b.WriteByte('_')
+			}
+			r = unicode.ToLower(r)
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// protoGoName 与protoc-gen-go的字段命名规则一致
+func protoGoName(snake string) string {
+	var b strings.Builder
+	for _, part := range strings.Split(snake, "_") {
+		b.WriteString(upperFirst(part))
+	}
+	return b.String()
+}
+
+func upperFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+	return string(r)
+}
+
+func lowerFirst(s string) string {
+	if s == "" {
+		return s
+	}
+	r := []rune(s)
+	r[0] = unicode.ToLower(r[0])
+	return string(r)
+}
+
+const protoTemplate = `syntax = "proto3";
+
+package {{.Snake}};
+
+option go_package = "{{.Module}}/api/proto/{{.Snake}};{{.Snake}}pb";
+{{if .NeedsValue}}
+import "google/protobuf/struct.proto";
+{{end}}
+// {{.Name}}Service 由 service.{{.Interface}} 生成，非基础类型以JSON形式通过google.protobuf.Value传输
+service {{.Name}}Service {
+{{- range .Methods}}
+  rpc {{.Name}}({{.Name}}Request) returns ({{.Name}}Response);
+{{- end}}
+}
+{{range .Methods}}
+message {{.Name}}Request {
+{{- range .Params}}
+  {{.ProtoType}} {{.Name}} = {{.Number}};
+{{- end}}
+}
+
+message {{.Name}}Response {
+{{- range .Results}}
+  {{.ProtoType}} {{.Name}} = {{.Number}};
+{{- end}}
+}
+{{end -}}
+`
+
+const serverTemplate = `package grpcsvc
+
+import (
+	"context"
+
+	{{.Snake}}pb "{{.Module}}/api/proto/{{.Snake}}"
+	"{{.Module}}/internal/service"
+{{- if .NeedsValue}}
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+{{- end}}
+{{- range .Imports}}
+	{{.}}
+{{- end}}
+)
+
+// {{.Name}}Server 将service.{{.Interface}}适配为gRPC服务
+type {{.Name}}Server struct {
+	{{.Snake}}pb.Unimplemented{{.Name}}ServiceServer
+	svc service.{{.Interface}}
+}
+
+// New{{.Name}}Server 构造函数
+func New{{.Name}}Server(svc service.{{.Interface}}) *{{.Name}}Server {
+	return &{{.Name}}Server{svc: svc}
+}
+{{$pb := printf "%spb" .Snake}}
+{{range $m := .Methods}}
+// {{$m.Name}} 调用service.{{$m.Name}}
+func (s *{{$.Name}}Server) {{$m.Name}}(ctx context.Context, req *{{$pb}}.{{$m.Name}}Request) (*{{$pb}}.{{$m.Name}}Response, error) {
+{{- range $m.Params}}
+{{- if .IsValue}}
+	var {{.Var}} {{.GoType}}
+	if err := fromValue(req.Get{{.GoName}}(), &{{.Var}}); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "{{.Name}}: %v", err)
+	}
+{{- else}}
+	{{.Var}} := {{.GoType}}(req.Get{{.GoName}}())
+{{- end}}
+{{- end}}
+
+	{{$m.Call}}
+{{- if $m.HasError}}
+	if err != nil {
+		return nil, toStatus(err)
+	}
+{{- end}}
+
+	resp := &{{$pb}}.{{$m.Name}}Response{}
+{{- range $i, $r := $m.Results}}
+{{- if .IsValue}}
+	v{{$i}}, err := toValue(r{{$i}})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "{{.Name}}: %v", err)
+	}
+	resp.{{.GoName}} = v{{$i}}
+{{- else}}
+	resp.{{.GoName}} = {{.ProtoGoType}}(r{{$i}})
+{{- end}}
+{{- end}}
+	return resp, nil
+}
+{{end}}
+`
+
+const convertTemplate = `package grpcsvc
+
+import (
+	"encoding/json"
+	"errors"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/types/known/structpb"
+	"gorm.io/gorm"
+)
+
+// toValue 将任意Go值经JSON转换为google.protobuf.Value
+func toValue(v interface{}) (*structpb.Value, error) {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var generic interface{}
+	if err := json.Unmarshal(raw, &generic); err != nil {
+		return nil, err
+	}
+	return structpb.NewValue(generic)
+}
+
+// fromValue 将google.protobuf.Value经JSON解析到dst
+func fromValue(v *structpb.Value, dst interface{}) error {
+	if v == nil {
+		return nil
+	}
+	raw, err := protojson.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(raw, dst)
+}
+
+// toStatus 将service层错误转换为gRPC状态码
+func toStatus(err error) error {
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+	switch {
+	case errors.Is(err, gorm.ErrRecordNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+`
@@ -0,0 +1,455 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"path/filepath"
+)
+
+const (
+	ModelDir      = "./internal/model"
+	ControllerDir = "./internal/controller"
+	ModulesDir    = "./internal/modules"
+)
+
+type moduleData struct {
+	Module string // go.mod 模块路径
+	Name   string // Order
+	Var    string // order
+	Snake  string // order_item，模块名称
+	Route  string // orders
+}
+
+// genModule 生成 model/dao/service/controller 骨架、自注册的功能模块及基于 mocks 的表驱动单元测试
+func genModule(args []string) {
+	if len(args) < 1 {
+		log.Fatal("Usage: turbo gen module <Name>")
+	}
+	name := upperFirst(args[0])
+	snake := toSnake(name)
+	data := moduleData{
+		Module: modulePath(),
+		Name:   name,
+		Var:    lowerFirst(name),
+		Snake:  snake,
+		Route:  snake + "s",
+	}
+
+	writeTemplate(filepath.Join(ModelDir, snake+".go"), moduleModelTemplate, data, true)
+	writeTemplate(filepath.Join(DAODir, snake+"_dao.go"), moduleDAOTemplate, data, true)
+	writeTemplate(filepath.Join(ServiceDir, snake+"_service.go"), moduleServiceTemplate, data, true)
+	writeTemplate(filepath.Join(ServiceDir, snake+"_service_test.go"), moduleServiceTestTemplate, data, true)
+	writeTemplate(filepath.Join(ControllerDir, snake+"_controller.go"), moduleControllerTemplate, data, true)
+	writeTemplate(filepath.Join(ControllerDir, snake+"_controller_test.go"), moduleControllerTestTemplate, data, true)
+	writeTemplate(filepath.Join(ModulesDir, snake+".go"), moduleRegisterTemplate, data, true)
+
+	// 测试骨架依赖新接口的替身
+	genMocks(nil)
+
+	fmt.Println("\nNext steps:")
+	fmt.Printf("  1. Adjust routes in internal/modules/%s.go (mounted under MODULES.ROUTE_PREFIX, e.g. /v1/%s/:id)\n", snake, data.Route)
+	fmt.Printf("  2. Set MODULES.AUTO_MIGRATE or migrate model.%s yourself\n", name)
+	fmt.Println("  3. go test ./internal/...")
+}
+
+const moduleModelTemplate = `package model
+
+import "gorm.io/gorm"
+
+type {{.Name}} struct {
+	gorm.Model
+	Name string ` + "`" + `gorm:"size:64" json:"name"` + "`" + `
+}
+`
+
+const moduleDAOTemplate = `package dao
+
+import (
+	"context"
+
+	"{{.Module}}/internal/model"
+	"gorm.io/gorm"
+)
+
+// I{{.Name}}DAO {{.Name}} 数据操作接口，可在此扩展自定义查询；方法接收请求上下文，审计、日志据此关联请求
+type I{{.Name}}DAO interface {
+	GetByID(ctx context.Context, id uint) (*model.{{.Name}}, error)
+	Create(ctx context.Context, {{.Var}} *model.{{.Name}}) error
+}
+
+// {{.Name}}DAO 实现 I{{.Name}}DAO
+type {{.Name}}DAO struct {
+	IBaseDAO[model.{{.Name}}]
+}
+
+func New{{.Name}}DAO(db *gorm.DB) I{{.Name}}DAO {
+	return &{{.Name}}DAO{
+		IBaseDAO: NewBaseDAO[model.{{.Name}}](db),
+	}
+}
+
+func (d {{.Name}}DAO) GetByID(ctx context.Context, id uint) (*model.{{.Name}}, error) {
+	return d.WithContext(ctx).GetByID(id)
+}
+
+func (d {{.Name}}DAO) Create(ctx context.Context, {{.Var}} *model.{{.Name}}) error {
+	return d.WithContext(ctx).Create({{.Var}})
+}
+`
+
+const moduleServiceTemplate = `package service
+
+import (
+	"context"
+
+	"{{.Module}}/internal/dao"
+	"{{.Module}}/internal/model"
+)
+
+type I{{.Name}}Service interface {
+	Get{{.Name}}(ctx context.Context, id uint) (*model.{{.Name}}, error)
+	Create{{.Name}}(ctx context.Context, {{.Var}} *model.{{.Name}}) error
+}
+
+type {{.Name}}Service struct {
+	{{.Var}}Dao dao.I{{.Name}}DAO
+}
+
+func New{{.Name}}Service({{.Var}}Dao dao.I{{.Name}}DAO) I{{.Name}}Service {
+	return &{{.Name}}Service{ {{- .Var}}Dao: {{.Var}}Dao}
+}
+
+func (s *{{.Name}}Service) Get{{.Name}}(ctx context.Context, id uint) (*model.{{.Name}}, error) {
+	return s.{{.Var}}Dao.GetByID(ctx, id)
+}
+
+func (s *{{.Name}}Service) Create{{.Name}}(ctx context.Context, {{.Var}} *model.{{.Name}}) error {
+	return s.{{.Var}}Dao.Create(ctx, {{.Var}})
+}
+`
+
+const moduleServiceTestTemplate = `package service_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"{{.Module}}/internal/mocks"
+	"{{.Module}}/internal/model"
+	"{{.Module}}/internal/service"
+	"gorm.io/gorm"
+)
+
+func Test{{.Name}}Service_Get{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      uint
+		setup   func(m *mocks.Mock{{.Name}}DAO)
+		wantErr error
+	}{
+		{
+			name: "found",
+			id:   1,
+			setup: func(m *mocks.Mock{{.Name}}DAO) {
+				m.GetByIDFunc = func(_ context.Context, id uint) (*model.{{.Name}}, error) {
+					return &model.{{.Name}}{Model: gorm.Model{ID: id}}, nil
+				}
+			},
+		},
+		{
+			name: "not found",
+			id:   2,
+			setup: func(m *mocks.Mock{{.Name}}DAO) {
+				m.GetByIDFunc = func(_ context.Context, id uint) (*model.{{.Name}}, error) {
+					return nil, gorm.ErrRecordNotFound
+				}
+			},
+			wantErr: gorm.ErrRecordNotFound,
+		},
+		// TODO: 补充业务用例
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks.Mock{{.Name}}DAO{}
+			tt.setup(m)
+
+			got, err := service.New{{.Name}}Service(m).Get{{.Name}}(context.Background(), tt.id)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Get{{.Name}}() error = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.ID != tt.id {
+				t.Errorf("Get{{.Name}}() ID = %d, want %d", got.ID, tt.id)
+			}
+			if n := len(m.Calls("GetByID")); n != 1 {
+				t.Errorf("GetByID called %d times, want 1", n)
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Service_Create{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   *model.{{.Name}}
+		setup   func(m *mocks.Mock{{.Name}}DAO)
+		wantErr bool
+	}{
+		{
+			name:  "ok",
+			input: &model.{{.Name}}{Name: "test"},
+			setup: func(m *mocks.Mock{{.Name}}DAO) {
+				m.CreateFunc = func(_ context.Context, {{.Var}} *model.{{.Name}}) error {
+					{{.Var}}.ID = 1
+					return nil
+				}
+			},
+		},
+		{
+			name:  "dao error",
+			input: &model.{{.Name}}{Name: "test"},
+			setup: func(m *mocks.Mock{{.Name}}DAO) {
+				m.CreateFunc = func(_ context.Context, {{.Var}} *model.{{.Name}}) error {
+					return errors.New("db down")
+				}
+			},
+			wantErr: true,
+		},
+		// TODO: 补充业务用例
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks.Mock{{.Name}}DAO{}
+			tt.setup(m)
+
+			err := service.New{{.Name}}Service(m).Create{{.Name}}(context.Background(), tt.input)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Create{{.Name}}() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+`
+
+const moduleControllerTemplate = `package controller
+
+import (
+	"context"
+
+	"{{.Module}}/internal/model"
+	"{{.Module}}/internal/service"
+)
+
+type {{.Name}}Controller struct {
+	{{.Var}}Service service.I{{.Name}}Service
+}
+
+func New{{.Name}}Controller({{.Var}}Service service.I{{.Name}}Service) *{{.Name}}Controller {
+	return &{{.Name}}Controller{ {{- .Var}}Service: {{.Var}}Service}
+}
+
+type Get{{.Name}}Request struct {
+	ID uint ` + "`" + `uri:"id" binding:"required"` + "`" + `
+}
+
+type Create{{.Name}}Request struct {
+	Name string ` + "`" + `json:"name" binding:"required,max=64"` + "`" + `
+}
+
+func (c *{{.Name}}Controller) Get{{.Name}}(ctx context.Context, req Get{{.Name}}Request) (*model.{{.Name}}, error) {
+	return c.{{.Var}}Service.Get{{.Name}}(ctx, req.ID)
+}
+
+func (c *{{.Name}}Controller) Create{{.Name}}(ctx context.Context, req Create{{.Name}}Request) (*model.{{.Name}}, error) {
+	{{.Var}} := &model.{{.Name}}{Name: req.Name}
+	if err := c.{{.Var}}Service.Create{{.Name}}(ctx, {{.Var}}); err != nil {
+		return nil, err
+	}
+	return {{.Var}}, nil
+}
+`
+
+const moduleRegisterTemplate = `package modules
+
+import (
+	"{{.Module}}/internal/controller"
+	"{{.Module}}/internal/dao"
+	"{{.Module}}/internal/model"
+	"{{.Module}}/internal/service"
+	"{{.Module}}/pkg/module"
+	"{{.Module}}/pkg/route"
+)
+
+func init() {
+	module.Register(func() module.Module { return &{{.Name}}{} })
+}
+
+// {{.Name}} 功能模块，可通过 MODULES.DISABLED 禁用
+type {{.Name}} struct {
+	module.Base
+
+	ctl *controller.{{.Name}}Controller
+}
+
+func (m *{{.Name}}) Name() string { return "{{.Snake}}" }
+
+func (m *{{.Name}}) Requires() []string { return []string{"DATABASE"} }
+
+func (m *{{.Name}}) Providers() []module.Provider {
+	return []module.Provider{
+		func(d *module.Deps) error {
+			{{.Var}}Service := service.New{{.Name}}Service(dao.New{{.Name}}DAO(d.DB))
+			module.Provide(d, {{.Var}}Service)
+
+			m.ctl = controller.New{{.Name}}Controller({{.Var}}Service)
+			return nil
+		},
+	}
+}
+
+func (m *{{.Name}}) Migrations() []interface{} {
+	return []interface{}{&model.{{.Name}}{}}
+}
+
+func (m *{{.Name}}) RegisterRoutes(r *route.Router) {
+	{{.Var}}s := r.Group("/{{.Route}}").Auth()
+	{
+		route.GET({{.Var}}s, "/:id", m.ctl.Get{{.Name}}).Doc("查询{{.Name}}")
+		route.POST({{.Var}}s, "", m.ctl.Create{{.Name}}).Doc("创建{{.Name}}")
+	}
+}
+`
+
+const moduleControllerTestTemplate = `package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"{{.Module}}/internal/controller"
+	"{{.Module}}/internal/mocks"
+	"{{.Module}}/internal/model"
+	"{{.Module}}/pkg/route"
+	"gorm.io/gorm"
+)
+
+func new{{.Name}}Router(m *mocks.Mock{{.Name}}Service) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	ctl := controller.New{{.Name}}Controller(m)
+	r := gin.New()
+	r.GET("/{{.Route}}/:id", route.Handle(ctl.Get{{.Name}}))
+	r.POST("/{{.Route}}", route.Handle(ctl.Create{{.Name}}))
+	return r
+}
+
+func Test{{.Name}}Controller_Get{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		setup      func(m *mocks.Mock{{.Name}}Service)
+		wantStatus int
+	}{
+		{
+			name: "found",
+			path: "/{{.Route}}/1",
+			setup: func(m *mocks.Mock{{.Name}}Service) {
+				m.Get{{.Name}}Func = func(_ context.Context, id uint) (*model.{{.Name}}, error) {
+					return &model.{{.Name}}{Model: gorm.Model{ID: id}}, nil
+				}
+			},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name: "not found",
+			path: "/{{.Route}}/2",
+			setup: func(m *mocks.Mock{{.Name}}Service) {
+				m.Get{{.Name}}Func = func(_ context.Context, id uint) (*model.{{.Name}}, error) {
+					return nil, gorm.ErrRecordNotFound
+				}
+			},
+			wantStatus: http.StatusNotFound,
+		},
+		{
+			name:       "invalid id",
+			path:       "/{{.Route}}/abc",
+			setup:      func(m *mocks.Mock{{.Name}}Service) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		// TODO: 补充业务用例
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks.Mock{{.Name}}Service{}
+			tt.setup(m)
+
+			w := httptest.NewRecorder()
+			new{{.Name}}Router(m).ServeHTTP(w, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+
+func Test{{.Name}}Controller_Create{{.Name}}(t *testing.T) {
+	tests := []struct {
+		name       string
+		body       string
+		setup      func(m *mocks.Mock{{.Name}}Service)
+		wantStatus int
+	}{
+		{
+			name: "created",
+			body: ` + "`" + `{"name":"test"}` + "`" + `,
+			setup: func(m *mocks.Mock{{.Name}}Service) {
+				m.Create{{.Name}}Func = func(_ context.Context, {{.Var}} *model.{{.Name}}) error { return nil }
+			},
+			wantStatus: http.StatusCreated,
+		},
+		{
+			name:       "bad json",
+			body:       ` + "`" + `{` + "`" + `,
+			setup:      func(m *mocks.Mock{{.Name}}Service) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name:       "missing name",
+			body:       ` + "`" + `{}` + "`" + `,
+			setup:      func(m *mocks.Mock{{.Name}}Service) {},
+			wantStatus: http.StatusBadRequest,
+		},
+		{
+			name: "service error",
+			body: ` + "`" + `{"name":"test"}` + "`" + `,
+			setup: func(m *mocks.Mock{{.Name}}Service) {
+				m.Create{{.Name}}Func = func(_ context.Context, {{.Var}} *model.{{.Name}}) error { return errors.New("db down") }
+			},
+			wantStatus: http.StatusInternalServerError,
+		},
+		// TODO: 补充业务用例
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m := &mocks.Mock{{.Name}}Service{}
+			tt.setup(m)
+
+			req := httptest.NewRequest(http.MethodPost, "/{{.Route}}", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			new{{.Name}}Router(m).ServeHTTP(w, req)
+			if w.Code != tt.wantStatus {
+				t.Fatalf("status = %d, want %d, body: %s", w.Code, tt.wantStatus, w.Body.String())
+			}
+		})
+	}
+}
+`
@@ -36,6 +36,12 @@ func main() {
 		installTools()
 	case "generate":
 		generateWire()
+	case "gen":
+		gen(os.Args[2:])
+	case "config":
+		configCmd(os.Args[2:])
+	case "routes":
+		routesCmd(os.Args[2:])
 	case "build":
 		build(false)
 	case "build-linux":
@@ -72,6 +78,22 @@ func installTools() {
 	runCommand("go", "install", "github.com/google/wire/cmd/wire@latest")
 }
 
+func gen(args []string) {
+	if len(args) < 1 {
+		log.Fatal("Usage: turbo gen <grpc|mocks|module> ...")
+	}
+	switch args[0] {
+	case "grpc":
+		genGRPC(args[1:])
+	case "mocks":
+		genMocks(args[1:])
+	case "module":
+		genModule(args[1:])
+	default:
+		log.Fatalf("Unknown generator: %s", args[0])
+	}
+}
+
 func generateWire() {
 	fmt.Println("Generating Wire dependencies...")
 	runCommand(WireCmd, "gen", WireGenPath)
@@ -141,6 +163,14 @@ func printHelp() {
 	fmt.Println("  deps           - Download all dependencies")
 	fmt.Println("  install-tools  - Install required tools (wire)")
 	fmt.Println("  generate       - Generate Wire dependencies")
+	fmt.Println("  gen grpc <Name> - Generate proto and gRPC server from service.I<Name>Service")
+	fmt.Println("  gen mocks      - Generate typed mocks for DAO and service interfaces into internal/mocks")
+	fmt.Println("  gen module <Name> - Generate model, DAO, service, controller and unit test skeletons")
+	fmt.Println("  config validate - Load and validate config (--config, --env, --env-file, --set)")
+	fmt.Println("  config print   - Print the effective config with secrets redacted (--format yaml|json)")
+	fmt.Println("  config docs    - Generate config reference (--format md|yaml, --output <file>)")
+	fmt.Println("  config env     - List environment variables recognized by config")
+	fmt.Println("  routes         - List routes with auth, permissions, rate limit and cache metadata (--format table|json|openapi, --output <file>)")
 	fmt.Println("  build          - Build the application")
 	fmt.Println("  build-linux    - Build the application for linux")
 	fmt.Println("  run            - Run the application")
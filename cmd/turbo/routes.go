@@ -0,0 +1,89 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/wire"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+)
+
+// routesCmd 列出已启用模块及 internal/router 注册的路由和元数据（认证、权限、限流策略、缓存）
+func routesCmd(args []string) {
+	fs := flag.NewFlagSet("routes", flag.ExitOnError)
+	format := fs.String("format", "table", "output format: table, json or openapi")
+	output := fs.String("output", "", "output file (default stdout)")
+	cfg, err := loadConfig(fs, args)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "" {
+		f, err := os.Create(*output)
+		if err != nil {
+			log.Fatalf("Create %s failed: %v", *output, err)
+		}
+		defer f.Close()
+		w = f
+	}
+
+	table, err := wire.RouteTable(cfg)
+	if err != nil {
+		log.Fatal(err)
+	}
+	switch *format {
+	case "table":
+		writeRouteTable(w, table.Routes())
+	case "json":
+		writeJSON(w, table.Routes())
+	case "openapi":
+		writeJSON(w, table.OpenAPI(route.Info{Title: ProjectName + " API", Version: config.Version}))
+	default:
+		log.Fatalf("Unknown format: %s", *format)
+	}
+	if *output != "" {
+		fmt.Printf("Generated %s\n", *output)
+	}
+}
+
+func writeRouteTable(w io.Writer, routes []*route.Route) {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "METHOD\tPATH\tAUTH\tPERMISSIONS\tRATE LIMIT\tCACHE\tHANDLER\tSUMMARY")
+	for _, rt := range routes {
+		auth := "-"
+		if rt.AuthRequired {
+			auth = "jwt"
+		}
+		cache := "-"
+		if rt.CacheTTL > 0 {
+			cache = rt.CacheTTL.String()
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			rt.Method, rt.Path, auth, orDash(strings.Join(rt.Permissions, ",")),
+			orDash(rt.RateLimitPolicy), cache, rt.Handler, rt.Summary)
+	}
+	_ = tw.Flush()
+}
+
+func writeJSON(w io.Writer, v interface{}) {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
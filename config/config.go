@@ -11,50 +11,180 @@ import (
 
 const Version = "0.0.1"
 
-// Config 全局配置结构体（自动生成文档）
+// Config 全局配置结构体（turbo config docs 根据标签生成文档）
 type Config struct {
 	Env        string           `mapstructure:"ENV" json:"env" yaml:"env" comment:"运行环境: dev/test/prod"`
-	Version    string           `mapstructure:"VERSION" json:"version" yaml:"version"`
+	Version    string           `mapstructure:"-" json:"version" yaml:"version"`
+	Files      []string         `mapstructure:"-" json:"files" yaml:"-" comment:"实际加载的配置文件（基础配置及环境覆盖文件）"`
 	Server     ServerConfig     `mapstructure:"SERVER" json:"server" yaml:"server"`
 	Database   DatabaseConfig   `mapstructure:"DATABASE" json:"database" yaml:"database"`
 	Redis      RedisConfig      `mapstructure:"REDIS" json:"redis" yaml:"redis"`
 	JWT        AuthConfig       `mapstructure:"JWT" json:"jwt" yaml:"jwt"`
 	Log        LogConfig        `mapstructure:"LOG" json:"log" yaml:"log"`
 	Middleware MiddlewareConfig `mapstructure:"MIDDLEWARE" json:"middleware" yaml:"middleware"`
+	Job        JobConfig        `mapstructure:"JOB" json:"job" yaml:"job"`
+	Lock       LockConfig       `mapstructure:"LOCK" json:"lock" yaml:"lock"`
+	Scheduler  SchedulerConfig  `mapstructure:"SCHEDULER" json:"scheduler" yaml:"scheduler"`
+	Realtime   RealtimeConfig   `mapstructure:"REALTIME" json:"realtime" yaml:"realtime"`
+	Secrets    SecretsConfig    `mapstructure:"SECRETS" json:"secrets" yaml:"secrets"`
+	Modules    ModulesConfig    `mapstructure:"MODULES" json:"modules" yaml:"modules"`
+
+	// secrets 加载时解析过的密钥引用，用于轮换时重新解析
+	secrets *secretResolver
 }
 
 // ServerConfig HTTP服务配置
 type ServerConfig struct {
-	Host           string        `mapstructure:"HOST" json:"host" yaml:"host" validate:"required,hostname"`
-	Port           int           `mapstructure:"PORT" json:"port" yaml:"port" validate:"required,min=1,max=65535"`
-	ReadTimeout    time.Duration `mapstructure:"READ_TIMEOUT" json:"read_timeout" yaml:"read_timeout"`
-	WriteTimeout   time.Duration `mapstructure:"WRITE_TIMEOUT" json:"write_timeout" yaml:"write_timeout"`
-	EnableSwagger  bool          `mapstructure:"ENABLE_SWAGGER" json:"enable_swagger" yaml:"enable_swagger"`
-	TrustedProxies []string      `mapstructure:"TRUSTED_PROXIES" json:"trusted_proxies" yaml:"trusted_proxies"`
+	Host            string        `mapstructure:"HOST" json:"host" yaml:"host" validate:"required,hostname|ip"`
+	Port            int           `mapstructure:"PORT" json:"port" yaml:"port" validate:"min=0,max=65535" comment:"0表示由系统分配端口"`
+	ReadTimeout     time.Duration `mapstructure:"READ_TIMEOUT" json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout    time.Duration `mapstructure:"WRITE_TIMEOUT" json:"write_timeout" yaml:"write_timeout"`
+	StartTimeout    time.Duration `mapstructure:"START_TIMEOUT" json:"start_timeout" yaml:"start_timeout" comment:"所有组件启动的超时时间"`
+	ShutdownTimeout time.Duration `mapstructure:"SHUTDOWN_TIMEOUT" json:"shutdown_timeout" yaml:"shutdown_timeout" comment:"优雅关闭的超时时间"`
+	DrainPeriod     time.Duration `mapstructure:"DRAIN_PERIOD" json:"drain_period" yaml:"drain_period" comment:"收到退出信号后健康检查先返回不可用, 等待该时长再关闭, 便于负载均衡摘除流量"`
+	EnableSwagger   bool          `mapstructure:"ENABLE_SWAGGER" json:"enable_swagger" yaml:"enable_swagger"`
+	TrustedProxies  []string      `mapstructure:"TRUSTED_PROXIES" json:"trusted_proxies" yaml:"trusted_proxies"`
+	H2C             bool          `mapstructure:"H2C" json:"h2c" yaml:"h2c" comment:"未启用TLS时允许明文HTTP/2(h2c), 适用于内网服务间调用"`
+	TLS             TLSConfig     `mapstructure:"TLS" json:"tls" yaml:"tls"`
+	Admin           AdminConfig   `mapstructure:"ADMIN" json:"admin" yaml:"admin"`
+	GRPC            GRPCConfig    `mapstructure:"GRPC" json:"grpc" yaml:"grpc"`
+}
+
+// GRPCConfig gRPC服务配置
+type GRPCConfig struct {
+	Enabled        bool `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Port           int  `mapstructure:"PORT" json:"port" yaml:"port" validate:"min=0,max=65535" comment:"0或与SERVER.PORT相同时与HTTP共用端口(按content-type分流)"`
+	Reflection     bool `mapstructure:"REFLECTION" json:"reflection" yaml:"reflection" comment:"开启服务反射, 便于grpcurl等工具调试"`
+	MaxRecvMsgSize int  `mapstructure:"MAX_RECV_MSG_SIZE" json:"max_recv_msg_size" yaml:"max_recv_msg_size" comment:"单条消息最大字节数"`
+}
+
+// Shared 是否与HTTP共用端口
+func (c *GRPCConfig) Shared(httpPort int) bool {
+	return c.Port == 0 || c.Port == httpPort
+}
+
+// AdminConfig 运维端口配置（健康检查、指标、pprof等不暴露在业务端口）
+type AdminConfig struct {
+	Enabled    bool   `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Host       string `mapstructure:"HOST" json:"host" yaml:"host" comment:"默认仅监听本机"`
+	Port       int    `mapstructure:"PORT" json:"port" yaml:"port" validate:"min=1,max=65535"`
+	Pprof      bool   `mapstructure:"PPROF" json:"pprof" yaml:"pprof" comment:"开启/debug/pprof"`
+	ConfigDump bool   `mapstructure:"CONFIG_DUMP" json:"config_dump" yaml:"config_dump" comment:"开启/config, 输出脱敏后的生效配置"`
+}
+
+// TLSConfig HTTPS配置
+type TLSConfig struct {
+	Enabled      bool     `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	CertFile     string   `mapstructure:"CERT_FILE" json:"cert_file" yaml:"cert_file" validate:"required_if=Enabled true"`
+	KeyFile      string   `mapstructure:"KEY_FILE" json:"key_file" yaml:"key_file" validate:"required_if=Enabled true"`
+	MinVersion   string   `mapstructure:"MIN_VERSION" json:"min_version" yaml:"min_version" validate:"oneof=1.0 1.1 1.2 1.3" comment:"最低TLS版本"`
+	CipherSuites []string `mapstructure:"CIPHER_SUITES" json:"cipher_suites" yaml:"cipher_suites" comment:"允许的加密套件(IANA名称), 为空时使用Go默认值, 仅对TLS1.2及以下生效"`
+	ClientCAFile string   `mapstructure:"CLIENT_CA_FILE" json:"client_ca_file" yaml:"client_ca_file" comment:"客户端证书CA, 配置后启用双向认证"`
+	ClientAuth   string   `mapstructure:"CLIENT_AUTH" json:"client_auth" yaml:"client_auth" validate:"oneof=none request require verify_if_given require_and_verify" comment:"客户端证书校验策略"`
+	WatchCerts   bool     `mapstructure:"WATCH_CERTS" json:"watch_certs" yaml:"watch_certs" comment:"监听证书文件变化并自动重新加载, 无需重启"`
+	RedirectHTTP bool     `mapstructure:"REDIRECT_HTTP" json:"redirect_http" yaml:"redirect_http" comment:"额外监听HTTP端口并重定向到HTTPS"`
+	RedirectPort int      `mapstructure:"REDIRECT_PORT" json:"redirect_port" yaml:"redirect_port"`
 }
 
 // DatabaseConfig 数据库配置
 type DatabaseConfig struct {
-	Enabled      bool   `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
-	Driver       string `mapstructure:"DRIVER" json:"driver" yaml:"driver" validate:"oneof=mysql postgres sqlite"`
-	DSN          string `mapstructure:"DSN" json:"dsn" yaml:"dsn" validate:"required_if=Enabled true"`
-	MaxIdleConns int    `mapstructure:"MAX_IDLE_CONNS" json:"max_idle_conns" yaml:"max_idle_conns"`
-	MaxOpenConns int    `mapstructure:"MAX_OPEN_CONNS" json:"max_open_conns" yaml:"max_open_conns"`
-	LogLevel     string `mapstructure:"LOG_LEVEL" json:"log_level" yaml:"log_level" validate:"oneof=silent error warn info"`
+	Enabled         bool          `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Driver          string        `mapstructure:"DRIVER" json:"driver" yaml:"driver" validate:"required" comment:"内置mysql, 其他驱动通过db.RegisterDriver注册, 未注册的驱动无法通过校验"`
+	DSN             string        `mapstructure:"DSN" json:"dsn" yaml:"dsn" validate:"required_if=Enabled true" secret:"true"`
+	MaxIdleConns    int           `mapstructure:"MAX_IDLE_CONNS" json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxOpenConns    int           `mapstructure:"MAX_OPEN_CONNS" json:"max_open_conns" yaml:"max_open_conns"`
+	LogLevel        string        `mapstructure:"LOG_LEVEL" json:"log_level" yaml:"log_level" validate:"oneof=silent error warn info" comment:"SQL日志级别: silent/error/warn(含慢查询)/info(全部SQL)"`
+	SlowThreshold   time.Duration `mapstructure:"SLOW_THRESHOLD" json:"slow_threshold" yaml:"slow_threshold" comment:"慢查询阈值, 为0时不记录慢查询"`
+	LogParams       bool          `mapstructure:"LOG_PARAMS" json:"log_params" yaml:"log_params" comment:"SQL日志是否输出参数值, 关闭时保留占位符"`
+	ConnMaxLifetime time.Duration `mapstructure:"CONN_MAX_LIFETIME" json:"conn_max_lifetime" yaml:"conn_max_lifetime" comment:"连接最大存活时间"`
+	ConnMaxIdleTime time.Duration `mapstructure:"CONN_MAX_IDLE_TIME" json:"conn_max_idle_time" yaml:"conn_max_idle_time" comment:"连接最大空闲时间"`
+	Replicas        []string      `mapstructure:"REPLICAS" json:"replicas" yaml:"replicas" secret:"true" comment:"只读副本DSN列表, 查询随机路由到副本, 写操作和事务使用主库"`
+	Audit           AuditConfig   `mapstructure:"AUDIT" json:"audit" yaml:"audit"`
+	Purge           PurgeConfig   `mapstructure:"PURGE" json:"purge" yaml:"purge"`
+
+	// Sources 具名数据源, 键为数据源名称(小写), 通过 db.Sources 按名称获取
+	Sources map[string]DataSourceConfig `mapstructure:"SOURCES" json:"sources" yaml:"sources"`
+}
+
+// DataSourceConfig 具名数据源配置，未配置的连接池参数沿用主库配置
+type DataSourceConfig struct {
+	Driver       string   `mapstructure:"DRIVER" json:"driver" yaml:"driver" comment:"为空时与主库相同"`
+	DSN          string   `mapstructure:"DSN" json:"dsn" yaml:"dsn" validate:"required" secret:"true"`
+	Replicas     []string `mapstructure:"REPLICAS" json:"replicas" yaml:"replicas" secret:"true"`
+	MaxIdleConns int      `mapstructure:"MAX_IDLE_CONNS" json:"max_idle_conns" yaml:"max_idle_conns"`
+	MaxOpenConns int      `mapstructure:"MAX_OPEN_CONNS" json:"max_open_conns" yaml:"max_open_conns"`
+
+	ConnMaxLifetime time.Duration `mapstructure:"CONN_MAX_LIFETIME" json:"conn_max_lifetime" yaml:"conn_max_lifetime"`
+	ConnMaxIdleTime time.Duration `mapstructure:"CONN_MAX_IDLE_TIME" json:"conn_max_idle_time" yaml:"conn_max_idle_time"`
+}
+
+// Primary 主库的数据源配置
+func (c *DatabaseConfig) Primary() DataSourceConfig {
+	return DataSourceConfig{
+		Driver:       c.Driver,
+		DSN:          c.DSN,
+		Replicas:     c.Replicas,
+		MaxIdleConns: c.MaxIdleConns,
+		MaxOpenConns: c.MaxOpenConns,
+
+		ConnMaxLifetime: c.ConnMaxLifetime,
+		ConnMaxIdleTime: c.ConnMaxIdleTime,
+	}
+}
+
+// AuditConfig 数据变更审计配置
+type AuditConfig struct {
+	Enabled     bool   `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Sink        string `mapstructure:"SINK" json:"sink" yaml:"sink" validate:"oneof=table logger" comment:"审计记录输出: table写入审计表/logger写入独立日志"`
+	Table       string `mapstructure:"TABLE" json:"table" yaml:"table" comment:"审计表名"`
+	AutoMigrate bool   `mapstructure:"AUTO_MIGRATE" json:"auto_migrate" yaml:"auto_migrate" comment:"启动时自动创建审计表"`
+	MaxRows     int    `mapstructure:"MAX_ROWS" json:"max_rows" yaml:"max_rows" comment:"批量更新/删除最多影响的行数, 超出时语句返回错误且不执行, 0表示不限制"`
+	LogFile     string `mapstructure:"LOG_FILE" json:"log_file" yaml:"log_file" comment:"SINK为logger时的独立日志文件, 为空时写入应用日志"`
+}
+
+// PurgeConfig 软删除记录定期清理配置
+type PurgeConfig struct {
+	Enabled  bool                     `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Schedule string                   `mapstructure:"SCHEDULE" json:"schedule" yaml:"schedule" comment:"清理任务的cron表达式, 由SCHEDULER执行"`
+	Models   map[string]time.Duration `mapstructure:"MODELS" json:"models" yaml:"models" comment:"表名到保留时长的映射, 软删除超过保留时长的记录将被彻底删除"`
 }
 
 // RedisConfig Redis配置
 type RedisConfig struct {
-	Enabled  bool   `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
-	Addr     string `mapstructure:"ADDR" json:"addr" yaml:"addr" validate:"required_if=Enabled true"`
-	Password string `mapstructure:"PASSWORD" json:"password" yaml:"password"`
-	DB       int    `mapstructure:"DB" json:"db" yaml:"db"`
+	Enabled          bool           `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Mode             string         `mapstructure:"MODE" json:"mode" yaml:"mode" validate:"oneof=standalone sentinel cluster" comment:"部署模式: standalone/sentinel/cluster, 不区分大小写"`
+	Addr             string         `mapstructure:"ADDR" json:"addr" yaml:"addr" comment:"单机模式地址, 也可通过ADDRS配置"`
+	Addrs            []string       `mapstructure:"ADDRS" json:"addrs" yaml:"addrs" comment:"哨兵或集群节点地址列表"`
+	MasterName       string         `mapstructure:"MASTER_NAME" json:"master_name" yaml:"master_name" comment:"哨兵模式主节点名称"`
+	Username         string         `mapstructure:"USERNAME" json:"username" yaml:"username"`
+	Password         string         `mapstructure:"PASSWORD" json:"password" yaml:"password" secret:"true"`
+	SentinelUsername string         `mapstructure:"SENTINEL_USERNAME" json:"sentinel_username" yaml:"sentinel_username"`
+	SentinelPassword string         `mapstructure:"SENTINEL_PASSWORD" json:"sentinel_password" yaml:"sentinel_password" secret:"true"`
+	DB               int            `mapstructure:"DB" json:"db" yaml:"db" comment:"集群模式下忽略"`
+	PoolSize         int            `mapstructure:"POOL_SIZE" json:"pool_size" yaml:"pool_size" comment:"连接池大小, 0表示使用默认值(10*CPU)"`
+	MinIdleConns     int            `mapstructure:"MIN_IDLE_CONNS" json:"min_idle_conns" yaml:"min_idle_conns"`
+	DialTimeout      time.Duration  `mapstructure:"DIAL_TIMEOUT" json:"dial_timeout" yaml:"dial_timeout"`
+	ReadTimeout      time.Duration  `mapstructure:"READ_TIMEOUT" json:"read_timeout" yaml:"read_timeout"`
+	WriteTimeout     time.Duration  `mapstructure:"WRITE_TIMEOUT" json:"write_timeout" yaml:"write_timeout"`
+	PoolTimeout      time.Duration  `mapstructure:"POOL_TIMEOUT" json:"pool_timeout" yaml:"pool_timeout"`
+	ConnMaxIdleTime  time.Duration  `mapstructure:"CONN_MAX_IDLE_TIME" json:"conn_max_idle_time" yaml:"conn_max_idle_time"`
+	TLS              RedisTLSConfig `mapstructure:"TLS" json:"tls" yaml:"tls"`
+}
+
+// RedisTLSConfig Redis TLS配置
+type RedisTLSConfig struct {
+	Enabled            bool   `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	CertFile           string `mapstructure:"CERT_FILE" json:"cert_file" yaml:"cert_file" comment:"客户端证书(双向认证时使用)"`
+	KeyFile            string `mapstructure:"KEY_FILE" json:"key_file" yaml:"key_file"`
+	CAFile             string `mapstructure:"CA_FILE" json:"ca_file" yaml:"ca_file" comment:"自定义CA证书, 为空时使用系统证书"`
+	ServerName         string `mapstructure:"SERVER_NAME" json:"server_name" yaml:"server_name"`
+	InsecureSkipVerify bool   `mapstructure:"INSECURE_SKIP_VERIFY" json:"insecure_skip_verify" yaml:"insecure_skip_verify"`
 }
 
 // AuthConfig 认证配置
 type AuthConfig struct {
 	Enabled        bool          `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
-	Secret         string        `mapstructure:"SECRET" json:"secret" yaml:"secret" validate:"required,min=32"`
+	Secret         string        `mapstructure:"SECRET" json:"secret" yaml:"secret" validate:"required,min=32" secret:"true"`
 	ExpireDuration time.Duration `mapstructure:"EXPIRE_DURATION" json:"expire_duration" yaml:"expire_duration"`
 	Issuer         string        `mapstructure:"ISSUER" json:"issuer" yaml:"issuer"`
 }
@@ -62,7 +192,7 @@ type AuthConfig struct {
 // LogConfig 日志配置
 type LogConfig struct {
 	Level      string `mapstructure:"LEVEL" json:"level" yaml:"level" validate:"oneof=debug info warn error"`
-	Format     string `mapstructure:"FORMAT" json:"format" yaml:"format" validate:"oneof=json text"`
+	Format     string `mapstructure:"FORMAT" json:"format" yaml:"format" validate:"oneof=json console"`
 	Output     string `mapstructure:"OUTPUT" json:"output" yaml:"output" validate:"oneof=stdout file both"`
 	MaxSize    int    `mapstructure:"MAX_SIZE" json:"max_size" yaml:"max_size"` // MB
 	MaxBackups int    `mapstructure:"MAX_BACKUPS" json:"max_backups" yaml:"max_backups"`
@@ -81,7 +211,7 @@ type MiddlewareConfig struct {
 
 type CORSConfig struct {
 	Enabled          bool     `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
-	AllowOrigins     []string `mapstructure:"ALLOW_ORIGINS" json:"allow_origins" yaml:"allow_origins"`
+	AllowOrigins     []string `mapstructure:"ALLOW_ORIGINS" json:"allow_origins" yaml:"allow_origins" validate:"min=1" comment:"允许的来源, 为*时允许全部"`
 	AllowMethods     []string `mapstructure:"ALLOW_METHODS" json:"allow_methods" yaml:"allow_methods"`
 	AllowHeaders     []string `mapstructure:"ALLOW_HEADERS" json:"allow_headers" yaml:"allow_headers"`
 	ExposeHeaders    []string `mapstructure:"EXPOSE_HEADERS" json:"expose_headers" yaml:"expose_headers"`
@@ -92,45 +222,100 @@ type RateLimitConfig struct {
 	Enabled bool    `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
 	RPS     float64 `mapstructure:"RPS" json:"rps" yaml:"rps"`
 	Burst   int     `mapstructure:"BURST" json:"burst" yaml:"burst"`
+
+	// Policies 具名限流策略, 路由通过 RateLimit("strict") 引用, 在全局限流之外额外生效
+	Policies map[string]RateLimitPolicy `mapstructure:"POLICIES" json:"policies" yaml:"policies" validate:"dive"`
 }
 
-// Load 加载配置
-func Load() (*Config, error) {
-	v := viper.New()
-	v.SetConfigName("config")
-	v.SetConfigType("yaml")
-	v.AddConfigPath(".")
+// RateLimitPolicy 单个限流策略，按客户端IP计数
+type RateLimitPolicy struct {
+	RPS   float64 `mapstructure:"RPS" json:"rps" yaml:"rps" validate:"gt=0"`
+	Burst int     `mapstructure:"BURST" json:"burst" yaml:"burst" validate:"min=1"`
+}
 
-	// 环境变量配置
-	v.AutomaticEnv()
-	v.SetEnvPrefix("APP")
-	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+// JobConfig 后台任务队列配置
+type JobConfig struct {
+	Enabled           bool          `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Backend           string        `mapstructure:"BACKEND" json:"backend" yaml:"backend" validate:"oneof=redis memory" comment:"队列存储: redis/memory(仅用于测试或单实例)"`
+	Queues            []string      `mapstructure:"QUEUES" json:"queues" yaml:"queues" comment:"消费的队列列表"`
+	Concurrency       int           `mapstructure:"CONCURRENCY" json:"concurrency" yaml:"concurrency" validate:"min=1" comment:"每个队列的并发worker数"`
+	MaxRetries        int           `mapstructure:"MAX_RETRIES" json:"max_retries" yaml:"max_retries" comment:"默认最大重试次数, 超过后进入死信队列"`
+	RetryMinBackoff   time.Duration `mapstructure:"RETRY_MIN_BACKOFF" json:"retry_min_backoff" yaml:"retry_min_backoff"`
+	RetryMaxBackoff   time.Duration `mapstructure:"RETRY_MAX_BACKOFF" json:"retry_max_backoff" yaml:"retry_max_backoff"`
+	PollInterval      time.Duration `mapstructure:"POLL_INTERVAL" json:"poll_interval" yaml:"poll_interval" comment:"队列为空时的轮询间隔"`
+	VisibilityTimeout time.Duration `mapstructure:"VISIBILITY_TIMEOUT" json:"visibility_timeout" yaml:"visibility_timeout" validate:"min=1s" comment:"超时未确认的任务会重新入队, 处理函数的超时为其90%"`
+}
 
-	// 设置默认值
-	setDefaults(v)
+// LockConfig 分布式锁配置
+type LockConfig struct {
+	Backend string `mapstructure:"BACKEND" json:"backend" yaml:"backend" validate:"oneof=redis memory" comment:"锁存储: redis/memory(进程内锁, 不提供跨副本互斥, 仅用于测试或单实例)"`
+}
 
-	// 读取配置
-	if err := v.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
-			return nil, fmt.Errorf("读取配置文件失败: %w", err)
-		}
-	}
+// SchedulerConfig 定时任务配置
+type SchedulerConfig struct {
+	Enabled   bool          `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	Timezone  string        `mapstructure:"TIMEZONE" json:"timezone" yaml:"timezone" comment:"cron表达式使用的时区, 为空时使用本地时区"`
+	LeaderKey string        `mapstructure:"LEADER_KEY" json:"leader_key" yaml:"leader_key" validate:"required" comment:"选主锁名称, 同一集群内的副本需保持一致"`
+	LeaderTTL time.Duration `mapstructure:"LEADER_TTL" json:"leader_ttl" yaml:"leader_ttl" validate:"min=1s" comment:"选主锁有效期, 不小于1s, leader宕机后其他副本最迟在该时间后接管"`
+}
+
+// RealtimeConfig WebSocket/SSE推送配置
+type RealtimeConfig struct {
+	Enabled        bool          `mapstructure:"ENABLED" json:"enabled" yaml:"enabled"`
+	PingInterval   time.Duration `mapstructure:"PING_INTERVAL" json:"ping_interval" yaml:"ping_interval" comment:"心跳间隔, WebSocket发送ping, SSE发送注释行"`
+	PongTimeout    time.Duration `mapstructure:"PONG_TIMEOUT" json:"pong_timeout" yaml:"pong_timeout" comment:"超过该时间未收到pong则断开, 需大于PING_INTERVAL"`
+	WriteTimeout   time.Duration `mapstructure:"WRITE_TIMEOUT" json:"write_timeout" yaml:"write_timeout" comment:"单条消息写超时"`
+	MaxMessageSize int64         `mapstructure:"MAX_MESSAGE_SIZE" json:"max_message_size" yaml:"max_message_size" comment:"客户端上行消息最大字节数"`
+	SendBuffer     int           `mapstructure:"SEND_BUFFER" json:"send_buffer" yaml:"send_buffer" validate:"min=1" comment:"每个连接的发送队列长度"`
+	SlowConsumer   string        `mapstructure:"SLOW_CONSUMER" json:"slow_consumer" yaml:"slow_consumer" validate:"oneof=disconnect drop" comment:"发送队列满时的处理方式: disconnect断开连接/drop丢弃消息"`
+	MaxConnections int           `mapstructure:"MAX_CONNECTIONS" json:"max_connections" yaml:"max_connections" comment:"单实例最大连接数, 0表示不限制"`
+	AllowedOrigins []string      `mapstructure:"ALLOWED_ORIGINS" json:"allowed_origins" yaml:"allowed_origins" comment:"WebSocket允许的Origin, 为空时仅允许同源, *表示全部"`
+	Fanout         bool          `mapstructure:"FANOUT" json:"fanout" yaml:"fanout" comment:"通过Redis pub/sub将消息广播到所有副本"`
+	Channel        string        `mapstructure:"CHANNEL" json:"channel" yaml:"channel" comment:"Redis pub/sub频道名"`
+}
+
+// SecretsConfig 密钥引用配置
+//
+// 标记 secret:"true" 的配置项可以写成引用，加载时解析为实际值：
+// file:///run/secrets/jwt、env://NAME、vault://secret/data/app#jwt_secret
+type SecretsConfig struct {
+	RefreshInterval time.Duration `mapstructure:"REFRESH_INTERVAL" json:"refresh_interval" yaml:"refresh_interval" comment:"定期重新解析密钥引用以感知轮换, 0表示不刷新"`
+	Vault           VaultConfig   `mapstructure:"VAULT" json:"vault" yaml:"vault"`
+}
+
+// ModulesConfig 功能模块配置，模块通过 module.Register 自注册，默认全部启用
+type ModulesConfig struct {
+	Disabled    []string `mapstructure:"DISABLED" json:"disabled" yaml:"disabled" comment:"禁用的模块名称列表"`
+	RoutePrefix string   `mapstructure:"ROUTE_PREFIX" json:"route_prefix" yaml:"route_prefix" comment:"模块路由挂载的路径前缀"`
+	AutoMigrate bool     `mapstructure:"AUTO_MIGRATE" json:"auto_migrate" yaml:"auto_migrate" comment:"启动时对模块声明的模型执行AutoMigrate, 需启用DATABASE"`
+}
+
+// VaultConfig Vault(KV引擎)连接配置，配置后可使用 vault:// 引用
+type VaultConfig struct {
+	Addr      string        `mapstructure:"ADDR" json:"addr" yaml:"addr" comment:"Vault地址, 为空时使用VAULT_ADDR环境变量"`
+	Token     string        `mapstructure:"TOKEN" json:"token" yaml:"token" secret:"true" comment:"访问令牌, 为空时使用VAULT_TOKEN环境变量, 可写成file://引用"`
+	Namespace string        `mapstructure:"NAMESPACE" json:"namespace" yaml:"namespace" comment:"企业版命名空间"`
+	Timeout   time.Duration `mapstructure:"TIMEOUT" json:"timeout" yaml:"timeout" comment:"单次请求超时"`
+}
+
+// Defaults 只包含默认值的配置，不读取配置文件和环境变量，供测试等需要完全控制配置的场景使用
+func Defaults() (*Config, error) {
+	v := viper.New()
+	setDefaults(v)
 
-	// 解析到结构体
 	var cfg Config
-	if err := v.Unmarshal(&cfg); err != nil {
+	if err := unmarshal(v, &cfg); err != nil {
 		return nil, fmt.Errorf("解析配置失败: %w", err)
 	}
-
-	// 配置校验
-	if err := validateConfig(&cfg); err != nil {
-		return nil, fmt.Errorf("配置校验失败: %w", err)
-	}
-
 	cfg.Version = Version
 	return &cfg, nil
 }
 
+// Validate 校验配置
+func (c *Config) Validate() error {
+	return validateConfig(c)
+}
+
 func setDefaults(v *viper.Viper) {
 	// 服务器默认值
 	v.SetDefault("ENV", "dev")
@@ -138,7 +323,23 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("SERVER.PORT", 8080)
 	v.SetDefault("SERVER.READ_TIMEOUT", 30*time.Second)
 	v.SetDefault("SERVER.WRITE_TIMEOUT", 30*time.Second)
+	v.SetDefault("SERVER.START_TIMEOUT", 30*time.Second)
+	v.SetDefault("SERVER.SHUTDOWN_TIMEOUT", 15*time.Second)
+	v.SetDefault("SERVER.DRAIN_PERIOD", 0)
 	v.SetDefault("SERVER.ENABLE_SWAGGER", true)
+	v.SetDefault("SERVER.TLS.MIN_VERSION", "1.2")
+	v.SetDefault("SERVER.TLS.CLIENT_AUTH", "require_and_verify")
+	v.SetDefault("SERVER.TLS.WATCH_CERTS", true)
+	v.SetDefault("SERVER.TLS.REDIRECT_PORT", 80)
+	v.SetDefault("SERVER.ADMIN.ENABLED", false)
+	v.SetDefault("SERVER.ADMIN.HOST", "127.0.0.1")
+	v.SetDefault("SERVER.ADMIN.PORT", 9090)
+	v.SetDefault("SERVER.ADMIN.PPROF", true)
+	v.SetDefault("SERVER.ADMIN.CONFIG_DUMP", true)
+	v.SetDefault("SERVER.GRPC.ENABLED", false)
+	v.SetDefault("SERVER.GRPC.PORT", 9000)
+	v.SetDefault("SERVER.GRPC.REFLECTION", true)
+	v.SetDefault("SERVER.GRPC.MAX_RECV_MSG_SIZE", 4<<20)
 
 	// 数据库默认值
 	v.SetDefault("DATABASE.ENABLED", true)
@@ -146,10 +347,25 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("DATABASE.MAX_IDLE_CONNS", 10)
 	v.SetDefault("DATABASE.MAX_OPEN_CONNS", 100)
 	v.SetDefault("DATABASE.LOG_LEVEL", "warn")
+	v.SetDefault("DATABASE.SLOW_THRESHOLD", 200*time.Millisecond)
+	v.SetDefault("DATABASE.LOG_PARAMS", false)
+	v.SetDefault("DATABASE.CONN_MAX_LIFETIME", 30*time.Minute)
+	v.SetDefault("DATABASE.CONN_MAX_IDLE_TIME", 10*time.Minute)
+	v.SetDefault("DATABASE.AUDIT.ENABLED", false)
+	v.SetDefault("DATABASE.AUDIT.SINK", "table")
+	v.SetDefault("DATABASE.AUDIT.TABLE", "audit_logs")
+	v.SetDefault("DATABASE.AUDIT.AUTO_MIGRATE", true)
+	v.SetDefault("DATABASE.AUDIT.MAX_ROWS", 1000)
+	v.SetDefault("DATABASE.PURGE.ENABLED", false)
+	v.SetDefault("DATABASE.PURGE.SCHEDULE", "@daily")
 
 	// Redis默认值
 	v.SetDefault("REDIS.ENABLED", false)
+	v.SetDefault("REDIS.MODE", "standalone")
 	v.SetDefault("REDIS.DB", 0)
+	v.SetDefault("REDIS.DIAL_TIMEOUT", 5*time.Second)
+	v.SetDefault("REDIS.READ_TIMEOUT", 3*time.Second)
+	v.SetDefault("REDIS.WRITE_TIMEOUT", 3*time.Second)
 
 	// JWT默认值
 	v.SetDefault("JWT.EXPIRE_DURATION", 72*time.Hour)
@@ -162,6 +378,46 @@ func setDefaults(v *viper.Viper) {
 	v.SetDefault("LOG.MAX_SIZE", 100)
 	v.SetDefault("LOG.MAX_BACKUPS", 7)
 
+	// 任务队列默认值
+	v.SetDefault("JOB.ENABLED", false)
+	v.SetDefault("JOB.BACKEND", "redis")
+	v.SetDefault("JOB.QUEUES", []string{"default"})
+	v.SetDefault("JOB.CONCURRENCY", 4)
+	v.SetDefault("JOB.MAX_RETRIES", 5)
+	v.SetDefault("JOB.RETRY_MIN_BACKOFF", 5*time.Second)
+	v.SetDefault("JOB.RETRY_MAX_BACKOFF", time.Hour)
+	v.SetDefault("JOB.POLL_INTERVAL", time.Second)
+	v.SetDefault("JOB.VISIBILITY_TIMEOUT", 5*time.Minute)
+
+	// 分布式锁默认值
+	v.SetDefault("LOCK.BACKEND", "redis")
+
+	// 定时任务默认值
+	v.SetDefault("SCHEDULER.ENABLED", false)
+	v.SetDefault("SCHEDULER.LEADER_KEY", "scheduler:leader")
+	v.SetDefault("SCHEDULER.LEADER_TTL", 30*time.Second)
+
+	// 实时推送默认值
+	v.SetDefault("REALTIME.ENABLED", false)
+	v.SetDefault("REALTIME.PING_INTERVAL", 25*time.Second)
+	v.SetDefault("REALTIME.PONG_TIMEOUT", 60*time.Second)
+	v.SetDefault("REALTIME.WRITE_TIMEOUT", 10*time.Second)
+	v.SetDefault("REALTIME.MAX_MESSAGE_SIZE", 64<<10)
+	v.SetDefault("REALTIME.SEND_BUFFER", 256)
+	v.SetDefault("REALTIME.SLOW_CONSUMER", "disconnect")
+	v.SetDefault("REALTIME.MAX_CONNECTIONS", 0)
+	v.SetDefault("REALTIME.FANOUT", false)
+	v.SetDefault("REALTIME.CHANNEL", "realtime")
+
+	// 密钥引用默认值
+	v.SetDefault("SECRETS.REFRESH_INTERVAL", time.Minute)
+	v.SetDefault("SECRETS.VAULT.TIMEOUT", 5*time.Second)
+
+	// 功能模块默认值
+	v.SetDefault("MODULES.DISABLED", []string{})
+	v.SetDefault("MODULES.ROUTE_PREFIX", "/v1")
+	v.SetDefault("MODULES.AUTO_MIGRATE", false)
+
 	// 中间件默认值
 	v.SetDefault("MIDDLEWARE.CORS.ENABLED", true)
 	v.SetDefault("MIDDLEWARE.CORS.ALLOW_METHODS", []string{"GET", "POST", "PUT", "DELETE"})
@@ -171,17 +427,94 @@ func setDefaults(v *viper.Viper) {
 }
 
 func validateConfig(cfg *Config) error {
+	normalizeConfig(cfg)
+	if err := validateStruct(cfg); err != nil {
+		return err
+	}
+
 	// 校验DSN格式
 	if cfg.Database.Enabled {
 		if _, err := url.Parse(cfg.Database.DSN); err != nil {
 			return fmt.Errorf("数据库DSN格式错误: %w", err)
 		}
+		for name, src := range cfg.Database.Sources {
+			if src.DSN == "" {
+				return fmt.Errorf("数据源%s需要配置DSN", name)
+			}
+		}
+		if err := validateDrivers(&cfg.Database); err != nil {
+			return err
+		}
 	}
 
-	// 校验JWT密钥长度
-	if len(cfg.JWT.Secret) < 32 {
-		return fmt.Errorf("JWT密钥长度至少32位")
+	// 校验TLS证书配置
+	if cfg.Server.TLS.Enabled && (cfg.Server.TLS.CertFile == "" || cfg.Server.TLS.KeyFile == "") {
+		return fmt.Errorf("启用TLS时需要配置CERT_FILE和KEY_FILE")
+	}
+
+	// 共用端口时gRPC按明文HTTP/2分流, 无法在TLS内识别
+	if cfg.Server.GRPC.Enabled && cfg.Server.GRPC.Shared(cfg.Server.Port) && cfg.Server.TLS.Enabled {
+		return fmt.Errorf("启用TLS时gRPC需要配置独立端口SERVER.GRPC.PORT")
+	}
+
+	// 校验Redis部署模式
+	if cfg.Redis.Enabled {
+		if err := validateRedis(&cfg.Redis); err != nil {
+			return err
+		}
+	}
+
+	// 校验软删除清理的保留时长
+	if cfg.Database.Purge.Enabled && cfg.Database.Enabled {
+		for table, retention := range cfg.Database.Purge.Models {
+			if retention <= 0 {
+				return fmt.Errorf("软删除清理的保留时长必须大于0: %s", table)
+			}
+		}
+	}
+
+	// 校验功能之间的依赖
+	if err := validateDependencies(cfg); err != nil {
+		return err
 	}
 
 	return nil
 }
+
+// normalizeConfig 统一不区分大小写的枚举值，使校验与各组件的解析一致
+func normalizeConfig(cfg *Config) {
+	cfg.Redis.Mode = strings.ToLower(strings.TrimSpace(cfg.Redis.Mode))
+	if cfg.Redis.Mode == "" {
+		cfg.Redis.Mode = "standalone"
+	}
+}
+
+// validateRedis 按部署模式校验地址配置（地址规则只在此处定义，不使用 validate 标签）
+func validateRedis(cfg *RedisConfig) error {
+	var result ValidationError
+	switch cfg.Mode {
+	case "standalone":
+		if cfg.Addr == "" && len(cfg.Addrs) == 0 {
+			result = append(result, FieldError{Key: "REDIS.ADDR", Rule: "required_without=ADDRS", Message: "单机模式需要配置 ADDR 或 ADDRS"})
+		}
+	case "sentinel":
+		if cfg.MasterName == "" {
+			result = append(result, FieldError{Key: "REDIS.MASTER_NAME", Rule: "required_if=MODE sentinel", Message: "哨兵模式需要配置 MASTER_NAME"})
+		}
+		if len(cfg.Addrs) == 0 {
+			result = append(result, FieldError{Key: "REDIS.ADDRS", Rule: "required_if=MODE sentinel", Message: "哨兵模式需要配置哨兵节点地址"})
+		}
+	case "cluster":
+		if len(cfg.Addrs) == 0 {
+			result = append(result, FieldError{Key: "REDIS.ADDRS", Rule: "required_if=MODE cluster", Message: "集群模式需要配置节点地址"})
+		}
+	}
+
+	if cfg.TLS.Enabled && (cfg.TLS.CertFile == "") != (cfg.TLS.KeyFile == "") {
+		result = append(result, FieldError{Key: "REDIS.TLS.KEY_FILE", Rule: "required_with=CERT_FILE", Message: "客户端证书需要同时配置 CERT_FILE 和 KEY_FILE"})
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,64 @@
+package config
+
+// dependency 功能开启时要求同时启用的组件
+type dependency struct {
+	key      string // 功能对应的配置项，例如 JOB.BACKEND
+	requires string // 依赖的配置段，例如 REDIS
+	active   func(*Config) bool
+	enabled  func(*Config) bool
+}
+
+// dependencies 组件未启用时构造函数返回nil，依赖它的功能在启动阶段统一报错，而不是运行时空指针
+var dependencies = []dependency{
+	{
+		key:      "JOB.BACKEND",
+		requires: "REDIS",
+		active:   func(c *Config) bool { return c.Job.Enabled && c.Job.Backend == "redis" },
+		enabled:  redisEnabled,
+	},
+	{
+		key:      "REALTIME.FANOUT",
+		requires: "REDIS",
+		active:   func(c *Config) bool { return c.Realtime.Enabled && c.Realtime.Fanout },
+		enabled:  redisEnabled,
+	},
+	{
+		// 选主依赖分布式锁，进程内锁需显式配置 LOCK.BACKEND=memory
+		key:      "SCHEDULER.ENABLED",
+		requires: "REDIS",
+		active:   func(c *Config) bool { return c.Scheduler.Enabled && c.Lock.Backend == "redis" },
+		enabled:  redisEnabled,
+	},
+	{
+		key:      "DATABASE.PURGE.ENABLED",
+		requires: "SCHEDULER",
+		active:   func(c *Config) bool { return c.Database.Enabled && c.Database.Purge.Enabled },
+		enabled:  func(c *Config) bool { return c.Scheduler.Enabled },
+	},
+	{
+		key:      "MODULES.AUTO_MIGRATE",
+		requires: "DATABASE",
+		active:   func(c *Config) bool { return c.Modules.AutoMigrate },
+		enabled:  func(c *Config) bool { return c.Database.Enabled },
+	},
+}
+
+func redisEnabled(c *Config) bool { return c.Redis.Enabled }
+
+// validateDependencies 列出全部依赖未启用的功能
+func validateDependencies(cfg *Config) error {
+	var result ValidationError
+	for _, dep := range dependencies {
+		if dep.active(cfg) && !dep.enabled(cfg) {
+			result = append(result, FieldError{
+				Key:     dep.key,
+				Rule:    "requires=" + dep.requires,
+				Message: "需要启用 " + dep.requires + "（" + dep.requires + ".ENABLED=true）",
+			})
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
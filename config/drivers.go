@@ -0,0 +1,71 @@
+package config
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// 已注册的数据库驱动名称，pkg/db.RegisterDriver 注册方言时同步登记，校验配置时据此拒绝未注册的驱动
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]bool{"mysql": true}
+)
+
+// RegisterDriver 登记数据库驱动名称，通常由 db.RegisterDriver 调用
+func RegisterDriver(name string) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = true
+}
+
+// Drivers 已注册的数据库驱动名称
+func Drivers() []string {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	names := make([]string, 0, len(drivers))
+	for name := range drivers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func driverRegistered(name string) bool {
+	driversMu.RLock()
+	defer driversMu.RUnlock()
+	return drivers[name]
+}
+
+// validateDrivers 校验主库及具名数据源的驱动均已注册
+func validateDrivers(cfg *DatabaseConfig) error {
+	var result ValidationError
+	check := func(key, driver string) {
+		if driverRegistered(driver) {
+			return
+		}
+		result = append(result, FieldError{
+			Key:     key,
+			Rule:    "driver",
+			Message: fmt.Sprintf("不支持的数据库驱动 %q, 可选: %s（其他驱动需通过 db.RegisterDriver 注册）", driver, strings.Join(Drivers(), ", ")),
+		})
+	}
+
+	check("DATABASE.DRIVER", cfg.Driver)
+	names := make([]string, 0, len(cfg.Sources))
+	for name := range cfg.Sources {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		// 为空时与主库相同
+		if driver := cfg.Sources[name].Driver; driver != "" {
+			check("DATABASE.SOURCES."+name+".DRIVER", driver)
+		}
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
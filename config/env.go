@@ -0,0 +1,150 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-viper/mapstructure/v2"
+	"github.com/spf13/viper"
+)
+
+// EnvPrefix 环境变量前缀
+const EnvPrefix = "APP"
+
+// Field 一个配置项的说明，由结构体标签和 setDefaults 中的默认值生成
+type Field struct {
+	Key     string // 配置路径，例如 DATABASE.DSN；结构体映射中的字段以 <name> 占位
+	Env     string // 对应的环境变量，无法通过环境变量设置时为空
+	Type    string
+	Default string
+	Rules   string // validate 标签
+	Secret  bool
+	Comment string
+}
+
+// EnvName 配置路径对应的环境变量名，例如 SERVER.PORT -> APP_SERVER_PORT
+func EnvName(key string) string {
+	return EnvPrefix + "_" + strings.ReplaceAll(strings.ToUpper(key), ".", "_")
+}
+
+// Fields 列出 Config 中的全部配置项
+//
+// 值为结构体的映射（如 DATABASE.SOURCES）无法用单个环境变量表达，Env 为空，需写在配置文件中
+func Fields() []Field {
+	v := viper.New()
+	setDefaults(v)
+
+	var fields []Field
+	walkFields(reflect.TypeOf(Config{}), "", true, func(key string, f reflect.StructField, bindable bool) {
+		field := Field{
+			Key:     key,
+			Type:    strings.ReplaceAll(f.Type.String(), "time.Duration", "duration"),
+			Rules:   f.Tag.Get("validate"),
+			Secret:  f.Tag.Get("secret") == "true",
+			Comment: f.Tag.Get("comment"),
+		}
+		if bindable {
+			field.Env = EnvName(key)
+			field.Default = formatValue(v.Get(key))
+		}
+		fields = append(fields, field)
+	})
+	return fields
+}
+
+// bindEnvs 为每个配置项绑定环境变量，使未出现在配置文件中的键也能被环境变量覆盖
+func bindEnvs(v *viper.Viper) {
+	walkFields(reflect.TypeOf(Config{}), "", true, func(key string, _ reflect.StructField, bindable bool) {
+		if bindable {
+			_ = v.BindEnv(key, EnvName(key))
+		}
+	})
+}
+
+// walkFields 按 mapstructure 标签遍历配置项，对每个叶子字段调用 fn；
+// 结构体映射中的字段以 <name> 占位，bindable 为 false
+func walkFields(t reflect.Type, prefix string, bindable bool, fn func(key string, f reflect.StructField, bindable bool)) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("mapstructure")
+		if name == "-" || !f.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = strings.ToUpper(f.Name)
+		}
+		key := prefix + name
+
+		switch {
+		case f.Type.Kind() == reflect.Struct && f.Type != reflect.TypeOf(time.Time{}):
+			walkFields(f.Type, key+".", bindable, fn)
+		case f.Type.Kind() == reflect.Map && f.Type.Elem().Kind() == reflect.Struct:
+			walkFields(f.Type.Elem(), key+".<name>.", false, fn)
+		default:
+			fn(key, f, bindable)
+		}
+	}
+}
+
+// unmarshal 解析配置，支持字符串形式的列表 "a, b" 和映射 "k1=v1,k2=v2"（环境变量、--set）
+func unmarshal(v *viper.Viper, cfg *Config) error {
+	return v.Unmarshal(cfg, viper.DecodeHook(mapstructure.ComposeDecodeHookFunc(
+		mapstructure.StringToTimeDurationHookFunc(),
+		stringToSliceHook,
+		stringToMapHook,
+	)))
+}
+
+func stringToSliceHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Slice {
+		return data, nil
+	}
+	return splitList(data.(string)), nil
+}
+
+func stringToMapHook(from, to reflect.Type, data interface{}) (interface{}, error) {
+	if from.Kind() != reflect.String || to.Kind() != reflect.Map {
+		return data, nil
+	}
+	m := map[string]string{}
+	for _, pair := range splitList(data.(string)) {
+		k, val, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, fmt.Errorf("映射格式应为 k1=v1,k2=v2: %q", pair)
+		}
+		m[strings.TrimSpace(k)] = strings.TrimSpace(val)
+	}
+	return m, nil
+}
+
+// splitList 按逗号拆分并去掉空白项
+func splitList(s string) []string {
+	items := []string{}
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// formatValue 以环境变量的写法展示默认值
+func formatValue(value interface{}) string {
+	switch val := value.(type) {
+	case nil:
+		return ""
+	case []string:
+		return strings.Join(val, ",")
+	case map[string]interface{}:
+		pairs := make([]string, 0, len(val))
+		for k, item := range val {
+			pairs = append(pairs, k+"="+formatValue(item))
+		}
+		sort.Strings(pairs)
+		return strings.Join(pairs, ",")
+	}
+	return fmt.Sprint(value)
+}
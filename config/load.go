@@ -0,0 +1,211 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/subosito/gotenv"
+)
+
+// 配置优先级（低 -> 高）：默认值 < 配置文件 < 环境覆盖文件 < 环境变量(.env) < 命令行参数
+//
+// 配置文件按以下顺序查找：LoadOptions.File(--config) > APP_CONFIG > 当前目录、./config、
+// 可执行文件所在目录下的 config.yaml。确定 ENV 后，同目录下的 config.<ENV>.yaml
+// 会合并到基础配置之上。.env 中的变量只在进程环境中不存在时生效。
+
+// ConfigEnv 指定配置文件路径的环境变量
+const ConfigEnv = "APP_CONFIG"
+
+// LoadOptions 加载选项，通常由命令行参数填充
+type LoadOptions struct {
+	// File 配置文件路径，为空时依次使用 APP_CONFIG 和默认查找路径
+	File string
+	// Env 运行环境，非空时覆盖配置文件和 APP_ENV
+	Env string
+	// EnvFile .env 文件路径，为空时使用当前目录下的 .env（不存在时忽略）
+	EnvFile string
+	// Overrides 最高优先级的配置覆盖，键为配置路径，例如 SERVER.PORT
+	Overrides Overrides
+}
+
+// RegisterFlags 注册 --config、--env、--env-file、--set 参数，解析后返回的选项可直接传给 Load
+func RegisterFlags(fs *flag.FlagSet) *LoadOptions {
+	opts := &LoadOptions{}
+	fs.StringVar(&opts.File, "config", "", "config file path (default $APP_CONFIG, then config.yaml in ., ./config or the binary's directory)")
+	fs.StringVar(&opts.Env, "env", "", "runtime environment, selects the config.<env>.yaml overlay (overrides ENV/APP_ENV)")
+	fs.StringVar(&opts.EnvFile, "env-file", "", "dotenv file loaded into the environment (default .env, ignored if missing)")
+	fs.Var(&opts.Overrides, "set", "override a config key, e.g. --set SERVER.PORT=9090 (repeatable)")
+	return opts
+}
+
+// Overrides 命令行 --set KEY=VALUE 形式的覆盖项，实现 flag.Value 可重复指定
+type Overrides map[string]string
+
+func (o *Overrides) String() string {
+	if o == nil || *o == nil {
+		return ""
+	}
+	pairs := make([]string, 0, len(*o))
+	for k, v := range *o {
+		pairs = append(pairs, k+"="+v)
+	}
+	sort.Strings(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (o *Overrides) Set(s string) error {
+	key, value, ok := strings.Cut(s, "=")
+	if !ok || strings.TrimSpace(key) == "" {
+		return fmt.Errorf("格式应为 KEY=VALUE: %q", s)
+	}
+	if *o == nil {
+		*o = Overrides{}
+	}
+	(*o)[strings.ToUpper(strings.TrimSpace(key))] = value
+	return nil
+}
+
+// Load 按优先级加载配置并校验
+func Load(opts LoadOptions) (*Config, error) {
+	if err := loadEnvFile(opts.EnvFile); err != nil {
+		return nil, err
+	}
+
+	v := viper.New()
+	v.SetConfigType("yaml")
+
+	// 环境变量配置，逐项绑定使仅在环境变量中出现的键也能生效
+	v.AutomaticEnv()
+	v.SetEnvPrefix(EnvPrefix)
+	v.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	bindEnvs(v)
+
+	// 设置默认值
+	setDefaults(v)
+
+	// 命令行覆盖，viper 中 Set 的优先级最高
+	for key, value := range opts.Overrides {
+		v.Set(key, value)
+	}
+	if opts.Env != "" {
+		v.Set("ENV", opts.Env)
+	}
+
+	// 读取配置
+	files, err := readConfigFiles(v, opts.File)
+	if err != nil {
+		return nil, err
+	}
+
+	// 解析到结构体
+	var cfg Config
+	if err := unmarshal(v, &cfg); err != nil {
+		return nil, fmt.Errorf("解析配置失败: %w", err)
+	}
+
+	// 解析密钥引用，校验使用解析后的值
+	ctx, cancel := context.WithTimeout(context.Background(), secretsTimeout(&cfg))
+	defer cancel()
+	if err := resolveSecrets(ctx, &cfg); err != nil {
+		return nil, err
+	}
+
+	// 配置校验
+	if err := validateConfig(&cfg); err != nil {
+		return nil, fmt.Errorf("配置校验失败: %w", err)
+	}
+
+	cfg.Version = Version
+	cfg.Files = files
+	return &cfg, nil
+}
+
+// secretsTimeout 加载阶段解析全部密钥引用的总超时
+func secretsTimeout(cfg *Config) time.Duration {
+	if cfg.Secrets.Vault.Timeout > 0 {
+		return 6 * cfg.Secrets.Vault.Timeout
+	}
+	return 30 * time.Second
+}
+
+// readConfigFiles 读取基础配置文件并合并环境覆盖文件，返回实际加载的文件
+func readConfigFiles(v *viper.Viper, file string) ([]string, error) {
+	if file == "" {
+		file = os.Getenv(ConfigEnv)
+	}
+
+	var files []string
+	dir := "."
+	if file != "" {
+		// 显式指定的文件必须存在
+		v.SetConfigFile(file)
+		if err := v.ReadInConfig(); err != nil {
+			return nil, fmt.Errorf("读取配置文件失败: %w", err)
+		}
+	} else {
+		v.SetConfigName("config")
+		for _, path := range searchPaths() {
+			v.AddConfigPath(path)
+		}
+		if err := v.ReadInConfig(); err != nil {
+			if _, ok := err.(viper.ConfigFileNotFoundError); !ok {
+				return nil, fmt.Errorf("读取配置文件失败: %w", err)
+			}
+		}
+	}
+	base := "config.yaml"
+	if used := v.ConfigFileUsed(); used != "" {
+		files = append(files, used)
+		dir, base = filepath.Split(used)
+	}
+
+	env := v.GetString("ENV")
+	if env == "" {
+		return files, nil
+	}
+	ext := filepath.Ext(base)
+	overlay := filepath.Join(dir, strings.TrimSuffix(base, ext)+"."+env+ext)
+	if _, err := os.Stat(overlay); err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return files, nil
+		}
+		return nil, fmt.Errorf("读取环境配置文件失败: %w", err)
+	}
+	v.SetConfigFile(overlay)
+	if err := v.MergeInConfig(); err != nil {
+		return nil, fmt.Errorf("合并环境配置文件 %s 失败: %w", overlay, err)
+	}
+	return append(files, overlay), nil
+}
+
+// searchPaths 未指定配置文件时的查找目录，使二进制不必在项目根目录运行
+func searchPaths() []string {
+	paths := []string{".", "./config"}
+	if exe, err := os.Executable(); err == nil {
+		paths = append(paths, filepath.Dir(exe))
+	}
+	return paths
+}
+
+// loadEnvFile 将 .env 中的变量写入进程环境，已存在的变量不会被覆盖
+func loadEnvFile(path string) error {
+	explicit := path != ""
+	if !explicit {
+		path = ".env"
+	}
+	if err := gotenv.Load(path); err != nil {
+		if !explicit && errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		return fmt.Errorf("读取 %s 失败: %w", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,46 @@
+package config
+
+import "reflect"
+
+// RedactedValue 脱敏后的占位符
+const RedactedValue = "******"
+
+// Redacted 返回脱敏后的配置副本，标记了 secret:"true" 的非空字段会被替换为占位符
+// 用于日志输出和配置导出，原配置不受影响
+func (c *Config) Redacted() *Config {
+	cp := *c
+	redact(reflect.ValueOf(&cp).Elem())
+	return &cp
+}
+
+func redact(v reflect.Value) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		switch {
+		case field.Kind() == reflect.Struct:
+			redact(field)
+		case t.Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "":
+			field.SetString(RedactedValue)
+		case t.Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+			// 切片与原配置共享底层数组，替换为新切片
+			redacted := reflect.MakeSlice(field.Type(), field.Len(), field.Len())
+			for j := 0; j < field.Len(); j++ {
+				if field.Index(j).String() != "" {
+					redacted.Index(j).SetString(RedactedValue)
+				}
+			}
+			field.Set(redacted)
+		case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Struct && !field.IsNil():
+			redacted := reflect.MakeMapWithSize(field.Type(), field.Len())
+			iter := field.MapRange()
+			for iter.Next() {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				elem.Set(iter.Value())
+				redact(elem)
+				redacted.SetMapIndex(iter.Key(), elem)
+			}
+			field.Set(redacted)
+		}
+	}
+}
@@ -0,0 +1,237 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrSecretNotFound 引用指向的密钥不存在
+var ErrSecretNotFound = errors.New("secret not found")
+
+// SecretProvider 解析密钥引用，ref 为去掉 "scheme://" 前缀后的部分
+type SecretProvider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// SecretProviderFunc 函数形式的 SecretProvider
+type SecretProviderFunc func(ctx context.Context, ref string) (string, error)
+
+func (f SecretProviderFunc) Resolve(ctx context.Context, ref string) (string, error) {
+	return f(ctx, ref)
+}
+
+var (
+	providersMu     sync.RWMutex
+	secretProviders = map[string]SecretProvider{
+		"file": SecretProviderFunc(resolveFile),
+		"env":  SecretProviderFunc(resolveEnv),
+	}
+)
+
+// RegisterSecretProvider 注册密钥提供者，需在 Load 之前调用；同名 scheme 会被覆盖
+func RegisterSecretProvider(scheme string, p SecretProvider) {
+	providersMu.Lock()
+	defer providersMu.Unlock()
+	secretProviders[scheme] = p
+}
+
+// resolveFile file:///run/secrets/jwt，去掉末尾换行
+func resolveFile(_ context.Context, path string) (string, error) {
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, path)
+	}
+	if err != nil {
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// resolveEnv env://NAME
+func resolveEnv(_ context.Context, name string) (string, error) {
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("%w: env %s", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// SecretRef 通过引用加载的配置项
+type SecretRef struct {
+	Key   string // 配置路径，例如 JWT.SECRET
+	Ref   string // 引用，例如 file:///run/secrets/jwt
+	Value string // 加载时解析得到的值
+}
+
+// secretResolver 保存本次加载可用的提供者（含 Vault）和解析过的引用
+type secretResolver struct {
+	providers map[string]SecretProvider
+	refs      []SecretRef
+}
+
+// SecretRefs 返回加载时解析过的引用
+func (c *Config) SecretRefs() []SecretRef {
+	if c.secrets == nil {
+		return nil
+	}
+	return append([]SecretRef(nil), c.secrets.refs...)
+}
+
+// ResolveSecret 解析引用，用于运行期感知密钥轮换；不是引用的值原样返回
+func (c *Config) ResolveSecret(ctx context.Context, ref string) (string, error) {
+	providers := registeredProviders()
+	if c.secrets != nil {
+		providers = c.secrets.providers
+	}
+	value, _, err := resolveRef(ctx, providers, ref)
+	return value, err
+}
+
+func registeredProviders() map[string]SecretProvider {
+	providersMu.RLock()
+	defer providersMu.RUnlock()
+	providers := make(map[string]SecretProvider, len(secretProviders)+1)
+	for scheme, p := range secretProviders {
+		providers[scheme] = p
+	}
+	return providers
+}
+
+// resolveRef 按 scheme 找到提供者并解析；未注册的 scheme 视为普通值
+func resolveRef(ctx context.Context, providers map[string]SecretProvider, value string) (string, bool, error) {
+	scheme, ref, ok := strings.Cut(value, "://")
+	if !ok {
+		return value, false, nil
+	}
+	p, ok := providers[scheme]
+	if !ok {
+		return value, false, nil
+	}
+	resolved, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return "", true, err
+	}
+	return resolved, true, nil
+}
+
+// resolveSecrets 解析所有标记 secret:"true" 的配置项中的引用
+//
+// Vault 令牌先用其余提供者解析，再据此创建 vault 提供者
+func resolveSecrets(ctx context.Context, cfg *Config) error {
+	r := &secretResolver{providers: registeredProviders()}
+
+	vault := &cfg.Secrets.Vault
+	if vault.Addr == "" {
+		vault.Addr = os.Getenv("VAULT_ADDR")
+	}
+	if vault.Token == "" {
+		vault.Token = os.Getenv("VAULT_TOKEN")
+	}
+	if err := r.resolve(ctx, "SECRETS.VAULT.TOKEN", &vault.Token); err != nil {
+		return err
+	}
+	if vault.Addr != "" {
+		if _, ok := r.providers["vault"]; !ok {
+			r.providers["vault"] = NewVaultProvider(vault)
+		}
+	}
+
+	var errs []error
+	walkSecrets(reflect.ValueOf(cfg).Elem(), "", func(key string, field reflect.Value) {
+		if key == "SECRETS.VAULT.TOKEN" {
+			return
+		}
+		value := field.String()
+		if err := r.resolve(ctx, key, &value); err != nil {
+			errs = append(errs, err)
+			return
+		}
+		field.SetString(value)
+	})
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	sort.Slice(r.refs, func(i, j int) bool { return r.refs[i].Key < r.refs[j].Key })
+	cfg.secrets = r
+	return nil
+}
+
+func (r *secretResolver) resolve(ctx context.Context, key string, value *string) error {
+	ref := *value
+	resolved, isRef, err := resolveRef(ctx, r.providers, ref)
+	if err != nil {
+		// 错误中只出现引用，不出现解析结果
+		return fmt.Errorf("解析 %s 的密钥引用 %s 失败: %w", key, ref, err)
+	}
+	if isRef {
+		r.refs = append(r.refs, SecretRef{Key: key, Ref: ref, Value: resolved})
+		*value = resolved
+	}
+	return nil
+}
+
+// walkSecrets 遍历标记 secret:"true" 的字符串字段（含切片元素、结构体映射中的字段）
+func walkSecrets(v reflect.Value, prefix string, fn func(key string, field reflect.Value)) {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("mapstructure")
+		if !f.IsExported() || name == "-" {
+			continue
+		}
+		key := prefix + name
+		field := v.Field(i)
+		secret := f.Tag.Get("secret") == "true"
+
+		switch {
+		case field.Kind() == reflect.Struct:
+			walkSecrets(field, key+".", fn)
+		case secret && field.Kind() == reflect.String:
+			fn(key, field)
+		case secret && field.Kind() == reflect.Slice && field.Type().Elem().Kind() == reflect.String:
+			for j := 0; j < field.Len(); j++ {
+				fn(key+"."+strconv.Itoa(j), field.Index(j))
+			}
+		case field.Kind() == reflect.Map && field.Type().Elem().Kind() == reflect.Struct:
+			// 映射元素不可寻址，解析副本后写回
+			iter := field.MapRange()
+			for iter.Next() {
+				elem := reflect.New(field.Type().Elem()).Elem()
+				elem.Set(iter.Value())
+				walkSecrets(elem, key+"."+iter.Key().String()+".", fn)
+				field.SetMapIndex(iter.Key(), elem)
+			}
+		}
+	}
+}
+
+// String 输出脱敏后的配置
+func (c *Config) String() string {
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err.Error()
+	}
+	return string(data)
+}
+
+// plainConfig 去掉自定义序列化方法，避免递归
+type plainConfig Config
+
+// MarshalJSON 序列化时始终脱敏，防止日志或导出泄露密钥
+func (c Config) MarshalJSON() ([]byte, error) {
+	return json.Marshal((*plainConfig)(c.Redacted()))
+}
+
+// MarshalYAML 同 MarshalJSON
+func (c Config) MarshalYAML() (interface{}, error) {
+	return (*plainConfig)(c.Redacted()), nil
+}
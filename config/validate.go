@@ -0,0 +1,168 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// FieldError 单个配置项未通过 validate 标签校验
+type FieldError struct {
+	Key     string // 配置路径，例如 SERVER.PORT
+	Rule    string // 未通过的规则，例如 max=65535
+	Message string
+}
+
+func (e FieldError) Error() string {
+	return e.Key + ": " + e.Message
+}
+
+// ValidationError 未通过校验的全部配置项
+type ValidationError []FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, len(e))
+	for i, fe := range e {
+		msgs[i] = fe.Error()
+	}
+	return strings.Join(msgs, "; ")
+}
+
+var (
+	validateOnce sync.Once
+	validate     *validator.Validate
+)
+
+// validateStruct 按 validate 标签校验，未启用（ENABLED=false）的配置段不校验
+func validateStruct(cfg *Config) error {
+	validateOnce.Do(func() {
+		validate = validator.New()
+		// 错误中使用配置路径而不是Go字段名
+		validate.RegisterTagNameFunc(func(f reflect.StructField) string {
+			return f.Tag.Get("mapstructure")
+		})
+	})
+
+	err := validate.Struct(cfg)
+	var verrs validator.ValidationErrors
+	if !errors.As(err, &verrs) {
+		return err
+	}
+
+	disabled := disabledSections(reflect.ValueOf(cfg).Elem(), "")
+	var result ValidationError
+	for _, fe := range verrs {
+		key := strings.TrimPrefix(fe.Namespace(), "Config.")
+		if underAny(key, disabled) {
+			continue
+		}
+		result = append(result, FieldError{
+			Key:     key,
+			Rule:    ruleString(fe.Tag(), fe.Param()),
+			Message: fieldMessage(fe, isSecret(cfg, fe.StructNamespace())),
+		})
+	}
+	if len(result) == 0 {
+		return nil
+	}
+	return result
+}
+
+// disabledSections 找出 ENABLED 为 false 的配置段
+func disabledSections(v reflect.Value, prefix string) []string {
+	var sections []string
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Type.Kind() != reflect.Struct {
+			continue
+		}
+		key := prefix + f.Tag.Get("mapstructure")
+		field := v.Field(i)
+		if enabled := field.FieldByName("Enabled"); enabled.IsValid() && enabled.Kind() == reflect.Bool && !enabled.Bool() {
+			sections = append(sections, key+".")
+			continue
+		}
+		sections = append(sections, disabledSections(field, key+".")...)
+	}
+	return sections
+}
+
+func underAny(key string, prefixes []string) bool {
+	for _, p := range prefixes {
+		if strings.HasPrefix(key, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// isSecret 根据Go字段路径（Config.JWT.Secret）判断是否为 secret 字段
+func isSecret(cfg *Config, structNamespace string) bool {
+	t := reflect.TypeOf(*cfg)
+	parts := strings.Split(structNamespace, ".")[1:]
+	for i, name := range parts {
+		f, ok := t.FieldByName(name)
+		if !ok {
+			return false
+		}
+		if i == len(parts)-1 {
+			return f.Tag.Get("secret") == "true"
+		}
+		t = f.Type
+	}
+	return false
+}
+
+func ruleString(tag, param string) string {
+	if param == "" {
+		return tag
+	}
+	return tag + "=" + param
+}
+
+// fieldMessage 将校验规则转为可读的说明，secret 字段不输出当前值
+func fieldMessage(fe validator.FieldError, secret bool) string {
+	var msg string
+	switch fe.Tag() {
+	case "required":
+		return "不能为空"
+	case "required_if":
+		cond := strings.Fields(fe.Param())
+		if len(cond) == 2 {
+			return fmt.Sprintf("%s 为 %s 时不能为空", cond[0], cond[1])
+		}
+		return "不能为空"
+	case "min":
+		switch fe.Kind() {
+		case reflect.String:
+			msg = fmt.Sprintf("长度不能小于 %s", fe.Param())
+		case reflect.Slice, reflect.Map:
+			msg = fmt.Sprintf("至少需要配置 %s 项", fe.Param())
+		default:
+			msg = fmt.Sprintf("不能小于 %s", fe.Param())
+		}
+	case "max":
+		if fe.Kind() == reflect.String {
+			msg = fmt.Sprintf("长度不能大于 %s", fe.Param())
+		} else {
+			msg = fmt.Sprintf("不能大于 %s", fe.Param())
+		}
+	case "gt":
+		msg = fmt.Sprintf("必须大于 %s", fe.Param())
+	case "oneof":
+		msg = "必须是以下之一: " + strings.Join(strings.Fields(fe.Param()), ", ")
+	case "hostname|ip":
+		msg = "必须是合法的主机名或IP"
+	default:
+		msg = "不满足规则 " + ruleString(fe.Tag(), fe.Param())
+	}
+	if secret {
+		return msg
+	}
+	return fmt.Sprintf("%s (当前值: %v)", msg, fe.Value())
+}
@@ -0,0 +1,134 @@
+package config
+
+import (
+	"errors"
+	"testing"
+)
+
+// testConfig 通过校验的默认配置，未启用数据库
+func testConfig(t *testing.T) *Config {
+	t.Helper()
+	cfg, err := Defaults()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.Database.Enabled = false
+	cfg.Middleware.CORS.AllowOrigins = []string{"*"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("default config: %v", err)
+	}
+	return cfg
+}
+
+func TestValidateRedisModeCaseInsensitive(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Redis.Enabled = true
+	cfg.Redis.Mode = "Cluster"
+	cfg.Redis.Addrs = []string{"127.0.0.1:7000"}
+
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+	if cfg.Redis.Mode != "cluster" {
+		t.Fatalf("Mode = %q, want cluster", cfg.Redis.Mode)
+	}
+}
+
+func TestValidateRedisAddrs(t *testing.T) {
+	tests := []struct {
+		name    string
+		mode    string
+		addr    string
+		addrs   []string
+		master  string
+		wantKey string
+	}{
+		{name: "standalone addr", mode: "standalone", addr: "127.0.0.1:6379"},
+		{name: "standalone addrs", mode: "standalone", addrs: []string{"127.0.0.1:6379"}},
+		{name: "standalone missing", mode: "standalone", wantKey: "REDIS.ADDR"},
+		{name: "sentinel missing master", mode: "sentinel", addrs: []string{"127.0.0.1:26379"}, wantKey: "REDIS.MASTER_NAME"},
+		{name: "sentinel", mode: "SENTINEL", addrs: []string{"127.0.0.1:26379"}, master: "mymaster"},
+		{name: "cluster missing", mode: "cluster", addr: "127.0.0.1:7000", wantKey: "REDIS.ADDRS"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := testConfig(t)
+			cfg.Redis.Enabled = true
+			cfg.Redis.Mode = tt.mode
+			cfg.Redis.Addr = tt.addr
+			cfg.Redis.Addrs = tt.addrs
+			cfg.Redis.MasterName = tt.master
+
+			err := cfg.Validate()
+			if tt.wantKey == "" {
+				if err != nil {
+					t.Fatalf("Validate() = %v, want nil", err)
+				}
+				return
+			}
+			var verr ValidationError
+			if !errors.As(err, &verr) || verr[0].Key != tt.wantKey {
+				t.Fatalf("Validate() = %v, want error on %s", err, tt.wantKey)
+			}
+		})
+	}
+}
+
+func TestValidateSchedulerLeaderTTL(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Scheduler.Enabled = true
+	cfg.Scheduler.LeaderTTL = 0
+
+	var verr ValidationError
+	if err := cfg.Validate(); !errors.As(err, &verr) || verr[0].Key != "SCHEDULER.LEADER_TTL" {
+		t.Fatalf("Validate() = %v, want error on SCHEDULER.LEADER_TTL", err)
+	}
+
+	cfg.Scheduler.Enabled = false
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("disabled scheduler should not be validated: %v", err)
+	}
+}
+
+func TestValidateSchedulerRequiresLock(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Scheduler.Enabled = true
+
+	var verr ValidationError
+	if err := cfg.Validate(); !errors.As(err, &verr) || verr[0].Key != "SCHEDULER.ENABLED" {
+		t.Fatalf("Validate() = %v, want error on SCHEDULER.ENABLED", err)
+	}
+
+	cfg.Lock.Backend = "memory"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("explicit in-process lock should be accepted: %v", err)
+	}
+}
+
+func TestValidateDatabaseDriver(t *testing.T) {
+	cfg := testConfig(t)
+	cfg.Database.Enabled = true
+	cfg.Database.DSN = "user:pass@tcp(127.0.0.1:3306)/app"
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	cfg.Database.Driver = "oracle"
+	cfg.Database.Sources = map[string]DataSourceConfig{
+		"analytics": {DSN: "analytics.db", Driver: "clickhouse"},
+		"reports":   {DSN: "user:pass@tcp(127.0.0.1:3306)/reports"},
+	}
+	var verr ValidationError
+	if err := cfg.Validate(); !errors.As(err, &verr) {
+		t.Fatalf("Validate() = %v, want ValidationError", err)
+	}
+	if len(verr) != 2 || verr[0].Key != "DATABASE.DRIVER" || verr[1].Key != "DATABASE.SOURCES.analytics.DRIVER" {
+		t.Fatalf("Validate() = %v, want errors on primary and analytics drivers", verr)
+	}
+
+	RegisterDriver("oracle")
+	RegisterDriver("clickhouse")
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("Validate() after RegisterDriver = %v, want nil", err)
+	}
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// VaultProvider 兼容 Vault KV 引擎 HTTP API 的密钥提供者
+//
+// 引用格式 vault://<path>#<field>，例如 vault://secret/data/app#jwt_secret 读取
+// GET {ADDR}/v1/secret/data/app 中的 jwt_secret 字段；同时支持 KV v1 与 v2 的响应格式，
+// 省略 #field 时读取 value 字段
+type VaultProvider struct {
+	cfg    *VaultConfig
+	client *http.Client
+}
+
+// NewVaultProvider 创建 Vault 提供者
+func NewVaultProvider(cfg *VaultConfig) *VaultProvider {
+	return &VaultProvider{cfg: cfg, client: &http.Client{Timeout: cfg.Timeout}}
+}
+
+func (p *VaultProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	path, field, ok := strings.Cut(ref, "#")
+	if !ok {
+		field = "value"
+	}
+
+	url := strings.TrimRight(p.cfg.Addr, "/") + "/v1/" + strings.TrimLeft(path, "/")
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", err
+	}
+	if p.cfg.Token != "" {
+		req.Header.Set("X-Vault-Token", p.cfg.Token)
+	}
+	if p.cfg.Namespace != "" {
+		req.Header.Set("X-Vault-Namespace", p.cfg.Namespace)
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("vault request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return "", fmt.Errorf("%w: vault %s", ErrSecretNotFound, path)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("vault %s: %s", path, resp.Status)
+	}
+
+	var body struct {
+		Data map[string]interface{} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return "", fmt.Errorf("vault %s: decode response: %w", path, err)
+	}
+
+	// KV v2 的字段位于 data.data 中
+	data := body.Data
+	if inner, ok := data["data"].(map[string]interface{}); ok {
+		if _, hasMeta := data["metadata"]; hasMeta {
+			data = inner
+		}
+	}
+	value, ok := data[field]
+	if !ok || value == nil {
+		return "", fmt.Errorf("%w: vault %s#%s", ErrSecretNotFound, path, field)
+	}
+	if s, ok := value.(string); ok {
+		return s, nil
+	}
+	return fmt.Sprint(value), nil
+}
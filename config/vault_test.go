@@ -0,0 +1,87 @@
+package config
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// vaultStub 模拟 Vault KV 引擎的 HTTP 接口
+func vaultStub(t *testing.T) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Vault-Token") != "root" {
+			http.Error(w, `{"errors":["permission denied"]}`, http.StatusForbidden)
+			return
+		}
+		if r.Header.Get("X-Vault-Namespace") != "team" {
+			http.Error(w, `{"errors":["namespace"]}`, http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v1/secret/data/app": // KV v2
+			_, _ = w.Write([]byte(`{"data":{"data":{"jwt_secret":"v2-secret","port":6379},"metadata":{"version":3}}}`))
+		case "/v1/kv/app": // KV v1
+			_, _ = w.Write([]byte(`{"data":{"value":"v1-secret","password":"p@ss"}}`))
+		case "/v1/broken":
+			_, _ = w.Write([]byte(`not json`))
+		default:
+			http.Error(w, `{"errors":[]}`, http.StatusNotFound)
+		}
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestVaultProviderResolve(t *testing.T) {
+	srv := vaultStub(t)
+	p := NewVaultProvider(&VaultConfig{Addr: srv.URL + "/", Token: "root", Namespace: "team", Timeout: time.Second})
+
+	tests := []struct {
+		ref      string
+		want     string
+		notFound bool
+		wantErr  bool
+	}{
+		{ref: "secret/data/app#jwt_secret", want: "v2-secret"},
+		{ref: "/secret/data/app#port", want: "6379"},
+		{ref: "kv/app", want: "v1-secret"},
+		{ref: "kv/app#password", want: "p@ss"},
+		{ref: "kv/app#missing", notFound: true},
+		{ref: "secret/data/other#x", notFound: true},
+		{ref: "broken", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.ref, func(t *testing.T) {
+			got, err := p.Resolve(context.Background(), tt.ref)
+			switch {
+			case tt.notFound:
+				if !errors.Is(err, ErrSecretNotFound) {
+					t.Fatalf("Resolve() = %q, %v; want ErrSecretNotFound", got, err)
+				}
+			case tt.wantErr:
+				if err == nil {
+					t.Fatalf("Resolve() = %q, want error", got)
+				}
+			default:
+				if err != nil || got != tt.want {
+					t.Fatalf("Resolve() = %q, %v; want %q", got, err, tt.want)
+				}
+			}
+		})
+	}
+}
+
+func TestVaultProviderUnauthorized(t *testing.T) {
+	srv := vaultStub(t)
+	p := NewVaultProvider(&VaultConfig{Addr: srv.URL, Token: "wrong", Namespace: "team", Timeout: time.Second})
+
+	_, err := p.Resolve(context.Background(), "secret/data/app#jwt_secret")
+	if err == nil || errors.Is(err, ErrSecretNotFound) {
+		t.Fatalf("Resolve() with bad token = %v, want a non-NotFound error", err)
+	}
+}
@@ -1,23 +1,14 @@
 package controller
 
-import (
-	"github.com/mjcode-max/TurboGin/internal/service"
-)
-
-// Container 集中管理所有控制器
+// Container 集中管理不属于功能模块的控制器
+//
+// 新功能优先实现为功能模块（internal/modules，turbo gen module 生成），
+// 由模块自行构建控制器并注册路由，无需修改此处
 type Container struct {
-	User *UserController
-
 	// 添加其他控制器...
 }
 
-// NewContainer 构造函数（依赖所有需要的Service）
-func NewContainer(
-	userService service.IUserService,
-
-	// 其他Service...
-) *Container {
-	return &Container{
-		User: NewUserController(userService),
-	}
+// NewContainer 构造函数（按需添加依赖的Service参数）
+func NewContainer() *Container {
+	return &Container{}
 }
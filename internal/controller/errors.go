@@ -0,0 +1,20 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/mjcode-max/TurboGin/internal/dao"
+	database "github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+	"gorm.io/gorm"
+)
+
+// 服务层返回的哨兵错误到HTTP状态码的映射，类型化处理函数（route.Handle）直接返回错误即可
+func init() {
+	route.RegisterError(gorm.ErrRecordNotFound, http.StatusNotFound)
+	route.RegisterError(dao.ErrConflict, http.StatusPreconditionFailed)
+	route.RegisterError(errPreconditionFailed, http.StatusPreconditionFailed)
+	route.RegisterError(database.ErrDisabled, http.StatusServiceUnavailable)
+	route.RegisterError(middleware.ErrAuthDisabled, http.StatusServiceUnavailable)
+}
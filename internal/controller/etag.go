@@ -0,0 +1,39 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+
+	"github.com/mjcode-max/TurboGin/pkg/route"
+)
+
+// errPreconditionFailed If-Match 格式错误，按不匹配处理
+var errPreconditionFailed = errors.New("precondition failed")
+
+// setETag 以记录版本号作为强 ETag；不是经 route.Handle 调用（单元测试、gRPC 等）时没有响应头可写，直接忽略
+func setETag(ctx context.Context, version uint) {
+	c := route.GinContext(ctx)
+	if c == nil {
+		return
+	}
+	c.Header("ETag", `"`+strconv.FormatUint(uint64(version), 10)+`"`)
+}
+
+// ifMatch 解析 If-Match 中的版本号；未携带或为 * 时 ok 为 false
+func ifMatch(header string) (version uint, ok bool, err error) {
+	header = strings.TrimSpace(header)
+	if header == "" || header == "*" {
+		return 0, false, nil
+	}
+	// If-Match 使用强比较，弱 ETag 与多个 ETag 均视为不匹配
+	if !strings.HasPrefix(header, `"`) || !strings.HasSuffix(header, `"`) || len(header) < 2 {
+		return 0, false, errPreconditionFailed
+	}
+	v, err := strconv.ParseUint(header[1:len(header)-1], 10, 0)
+	if err != nil {
+		return 0, false, errPreconditionFailed
+	}
+	return uint(v), true, nil
+}
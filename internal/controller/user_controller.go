@@ -1,11 +1,10 @@
 package controller
 
 import (
-	"github.com/gin-gonic/gin"
+	"context"
+
 	"github.com/mjcode-max/TurboGin/internal/model"
 	"github.com/mjcode-max/TurboGin/internal/service"
-	"net/http"
-	"strconv"
 )
 
 type UserController struct {
@@ -16,27 +15,69 @@ func NewUserController(userService service.IUserService) *UserController {
 	return &UserController{userService: userService}
 }
 
-func (c *UserController) GetUser(ctx *gin.Context) {
-	id, _ := strconv.Atoi(ctx.Param("id"))
-	user, err := c.userService.GetUser(uint(id))
+type GetUserRequest struct {
+	ID uint `uri:"id" binding:"required"`
+}
+
+type CreateUserRequest struct {
+	Name string `json:"name" binding:"max=64"`
+}
+
+// UpdateUserRequest 指针字段为nil表示请求体中未出现，不修改
+type UpdateUserRequest struct {
+	ID      uint    `uri:"id" binding:"required"`
+	IfMatch string  `header:"If-Match"`
+	Name    *string `json:"name" binding:"omitempty,max=64"`
+	Version *uint   `json:"version"`
+}
+
+func (c *UserController) GetUser(ctx context.Context, req GetUserRequest) (*model.User, error) {
+	user, err := c.userService.GetUser(ctx, req.ID)
 	if err != nil {
-		ctx.JSON(http.StatusNotFound, gin.H{"error": "User not found"})
-		return
+		return nil, err
 	}
-	ctx.JSON(http.StatusOK, user)
+	setETag(ctx, user.Version)
+	return user, nil
 }
 
-func (c *UserController) CreateUser(ctx *gin.Context) {
-	var user model.User
-	if err := ctx.ShouldBindJSON(&user); err != nil {
-		ctx.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
-		return
+func (c *UserController) CreateUser(ctx context.Context, req CreateUserRequest) (*model.User, error) {
+	user := &model.User{Name: req.Name}
+	if err := c.userService.CreateUser(ctx, user); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// UpdateUser 部分更新，只修改请求体中出现的字段；
+// 携带 If-Match 时以其中的版本号做乐观锁校验，版本不一致返回412
+func (c *UserController) UpdateUser(ctx context.Context, req UpdateUserRequest) (*model.User, error) {
+	user, err := c.userService.GetUser(ctx, req.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	version, ok, err := ifMatch(req.IfMatch)
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []string
+	if req.Name != nil {
+		user.Name = *req.Name
+		fields = append(fields, "name")
+	}
+	// 期望版本优先取 If-Match，其次取请求体中的 version
+	switch {
+	case ok:
+		user.Version = version
+	case req.Version != nil:
+		user.Version = *req.Version
 	}
 
-	if err := c.userService.CreateUser(&user); err != nil {
-		ctx.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
-		return
+	if err := c.userService.UpdateUser(ctx, user, fields); err != nil {
+		return nil, err
 	}
 
-	ctx.JSON(http.StatusCreated, user)
+	setETag(ctx, user.Version)
+	return user, nil
 }
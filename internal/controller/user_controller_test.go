@@ -0,0 +1,77 @@
+package controller_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/internal/controller"
+	"github.com/mjcode-max/TurboGin/internal/mocks"
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+	"gorm.io/gorm"
+)
+
+func userService(name string, version uint) *mocks.MockUserService {
+	return &mocks.MockUserService{
+		GetUserFunc: func(_ context.Context, id uint) (*model.User, error) {
+			return &model.User{Model: gorm.Model{ID: id}, Name: name, Version: version}, nil
+		},
+		UpdateUserFunc: func(_ context.Context, user *model.User, fields []string) error {
+			if user.Version != version {
+				return errors.New("version conflict")
+			}
+			if len(fields) > 0 {
+				user.Version++
+			}
+			return nil
+		},
+	}
+}
+
+// 不经 route.Handle 直接调用（单元测试、gRPC 适配）时没有 gin.Context，不能写 ETag
+func TestUserController_GetUserWithoutGinContext(t *testing.T) {
+	ctl := controller.NewUserController(userService("alice", 3))
+
+	user, err := ctl.GetUser(context.Background(), controller.GetUserRequest{ID: 1})
+	if err != nil {
+		t.Fatalf("GetUser: %v", err)
+	}
+	if user.ID != 1 || user.Name != "alice" || user.Version != 3 {
+		t.Fatalf("user = %+v", user)
+	}
+}
+
+func TestUserController_UpdateUserWithoutGinContext(t *testing.T) {
+	name := "bob"
+	tests := []struct {
+		name        string
+		req         controller.UpdateUserRequest
+		wantVersion uint
+		wantStatus  int
+	}{
+		{name: "if-match", req: controller.UpdateUserRequest{ID: 1, IfMatch: `"3"`, Name: &name}, wantVersion: 4},
+		{name: "no change", req: controller.UpdateUserRequest{ID: 1}, wantVersion: 3},
+		{name: "weak etag", req: controller.UpdateUserRequest{ID: 1, IfMatch: `W/"3"`, Name: &name}, wantStatus: http.StatusPreconditionFailed},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctl := controller.NewUserController(userService("alice", 3))
+
+			user, err := ctl.UpdateUser(context.Background(), tt.req)
+			if tt.wantStatus != 0 {
+				if status := route.StatusOf(err); status != tt.wantStatus {
+					t.Fatalf("UpdateUser = %v (status %d), want %d", err, status, tt.wantStatus)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdateUser: %v", err)
+			}
+			if user.Version != tt.wantVersion {
+				t.Fatalf("Version = %d, want %d", user.Version, tt.wantVersion)
+			}
+		})
+	}
+}
@@ -1,17 +1,78 @@
 package dao
 
 import (
+	"context"
+	"errors"
+	"reflect"
+	"slices"
+	"time"
+
+	database "github.com/mjcode-max/TurboGin/pkg/db"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
 )
 
+// ErrNotSoftDeletable 模型没有 gorm.DeletedAt 字段，无法使用回收站相关方法
+var ErrNotSoftDeletable = errors.New("dao: model has no gorm.DeletedAt field")
+
+// ErrConflict 乐观锁冲突：记录已被其他请求修改（或已删除），调用方应重新读取后再提交
+var ErrConflict = errors.New("dao: version conflict")
+
+// defaultBatchSize 批量写入、分批查询未指定批大小时的默认值
+const defaultBatchSize = 100
+
+// purgeBatchSize 彻底删除时每批处理的行数，避免长事务锁表
+const purgeBatchSize = 500
+
+var deletedAtType = reflect.TypeOf(gorm.DeletedAt{})
+
 // IBaseDAO 泛型 CRUD 接口
 type IBaseDAO[T any] interface {
 	Create(entity *T) error
 	GetByID(id uint) (*T, error)
+	// Update 更新全部字段；模型带有整数类型的 Version 字段时启用乐观锁，
+	// 版本不一致时返回 ErrConflict，成功后 entity.Version 自增
 	Update(entity *T) error
+	// UpdateFields 只更新指定字段（Go字段名或列名），乐观锁规则同 Update；
+	// 未指定字段时不写入，但仍校验版本，不一致时返回 ErrConflict
+	UpdateFields(entity *T, fields ...string) error
+	// Delete 模型带有 gorm.DeletedAt 时为软删除
 	Delete(id uint) error
 	Find(conditions interface{}, args ...interface{}) ([]T, error)
+	// CreateBatch 分批插入，batchSize<=0 时使用默认值100，插入后回填主键
+	CreateBatch(entities []T, batchSize int) error
+	// Upsert 插入，唯一键冲突时按 opts 更新或忽略
+	Upsert(entities []T, opts UpsertOptions) error
+	// UpdateWhere 按条件批量更新，返回影响行数；条件不能为空
+	UpdateWhere(values map[string]interface{}, conditions interface{}, args ...interface{}) (int64, error)
+	// DeleteWhere 按条件批量删除（软删除模型为软删除），返回影响行数；条件不能为空
+	DeleteWhere(conditions interface{}, args ...interface{}) (int64, error)
+	// FindInBatches 按主键顺序分批查询，fn 返回错误时停止；conditions 为 nil 时不加条件
+	FindInBatches(batchSize int, fn func(batch []T) error, conditions interface{}, args ...interface{}) error
+	// Each 使用游标逐行读取，适合大批量导出；fn 返回错误时停止
+	Each(fn func(entity *T) error, conditions interface{}, args ...interface{}) error
+	// FindDeleted 查询已软删除的记录
+	FindDeleted(conditions interface{}, args ...interface{}) ([]T, error)
+	// Restore 恢复已软删除的记录，记录不存在或未被删除时返回 gorm.ErrRecordNotFound
+	Restore(id uint) error
+	// ForceDelete 彻底删除记录（无论是否已软删除）
+	ForceDelete(id uint) error
+	// PurgeOlderThan 彻底删除软删除时间早于 age 之前的记录，返回删除行数
+	PurgeOlderThan(age time.Duration) (int64, error)
 	DB() *gorm.DB
+	// WithContext 返回绑定请求上下文的DAO（审计、超时控制等依赖上下文）
+	WithContext(ctx context.Context) IBaseDAO[T]
+}
+
+// UpsertOptions 冲突处理方式
+type UpsertOptions struct {
+	// Conflict 冲突判断的唯一键列，为空时使用主键；MySQL 忽略该项，按表上的唯一索引判断
+	Conflict []string
+	// Update 冲突时更新的列，为空时更新除主键、创建时间外的全部列
+	Update []string
+	// DoNothing 冲突时忽略该行
+	DoNothing bool
 }
 
 // BaseDAO 泛型 CRUD 实现
@@ -19,8 +80,9 @@ type BaseDAO[T any] struct {
 	db *gorm.DB
 }
 
+// NewBaseDAO 构造函数，未启用数据库（db为nil）时所有方法返回 db.ErrDisabled
 func NewBaseDAO[T any](db *gorm.DB) IBaseDAO[T] {
-	return &BaseDAO[T]{db: db}
+	return &BaseDAO[T]{db: database.OrDisabled(db)}
 }
 
 func (d *BaseDAO[T]) Create(entity *T) error {
@@ -36,7 +98,93 @@ func (d *BaseDAO[T]) GetByID(id uint) (*T, error) {
 }
 
 func (d *BaseDAO[T]) Update(entity *T) error {
-	return d.db.Save(entity).Error
+	s, err := d.schema()
+	if err != nil {
+		return err
+	}
+	if versionField(s) == nil {
+		return d.db.Save(entity).Error
+	}
+
+	rv := reflect.ValueOf(entity).Elem()
+	if s.PrioritizedPrimaryField != nil {
+		if _, zero := s.PrioritizedPrimaryField.ValueOf(d.db.Statement.Context, rv); zero {
+			return d.db.Create(entity).Error
+		}
+	}
+
+	// 与 Save 一致更新全部字段，但不覆盖主键和创建时间
+	omit := make([]string, 0, 2)
+	for _, field := range s.Fields {
+		if field.PrimaryKey || field.AutoCreateTime > 0 {
+			omit = append(omit, field.DBName)
+		}
+	}
+	return d.updateVersioned(entity, s, d.db.Select("*").Omit(omit...))
+}
+
+func (d *BaseDAO[T]) UpdateFields(entity *T, fields ...string) error {
+	s, err := d.schema()
+	if err != nil {
+		return err
+	}
+	if versionField(s) == nil {
+		if len(fields) == 0 {
+			return nil
+		}
+		return d.db.Model(entity).Select(fields).Updates(entity).Error
+	}
+	if len(fields) == 0 {
+		return d.checkVersion(entity, s)
+	}
+	return d.updateVersioned(entity, s, d.db.Select(append(fields[:len(fields):len(fields)], versionField(s).DBName)))
+}
+
+// checkVersion 不写入，仅确认记录存在且版本与 entity 一致，否则返回 ErrConflict
+func (d *BaseDAO[T]) checkVersion(entity *T, s *schema.Schema) error {
+	ctx := d.db.Statement.Context
+	rv := reflect.ValueOf(entity).Elem()
+	field := versionField(s)
+	version, _ := field.ValueOf(ctx, rv)
+
+	tx := d.db.Model(new(T)).Where(clause.Eq{Column: fieldColumn(field), Value: version})
+	for _, pk := range s.PrimaryFields {
+		value, _ := pk.ValueOf(ctx, rv)
+		tx = tx.Where(clause.Eq{Column: fieldColumn(pk), Value: value})
+	}
+	var count int64
+	if err := tx.Count(&count).Error; err != nil {
+		return err
+	}
+	if count == 0 {
+		return ErrConflict
+	}
+	return nil
+}
+
+// updateVersioned 以 WHERE version = 当前版本 更新并将版本加一，未命中时恢复版本并返回 ErrConflict
+func (d *BaseDAO[T]) updateVersioned(entity *T, s *schema.Schema, tx *gorm.DB) error {
+	ctx := d.db.Statement.Context
+	field := versionField(s)
+	rv := reflect.ValueOf(entity).Elem()
+
+	value, _ := field.ValueOf(ctx, rv)
+	current := reflect.ValueOf(value)
+	if err := field.Set(ctx, rv, versionAdd(current, 1)); err != nil {
+		return err
+	}
+
+	result := tx.Model(entity).
+		Where(clause.Eq{Column: fieldColumn(field), Value: value}).
+		Updates(entity)
+	if result.Error == nil && result.RowsAffected == 0 {
+		result.Error = ErrConflict
+	}
+	if result.Error != nil {
+		_ = field.Set(ctx, rv, value)
+		return result.Error
+	}
+	return nil
 }
 
 func (d *BaseDAO[T]) Delete(id uint) error {
@@ -50,6 +198,256 @@ func (d *BaseDAO[T]) Find(conditions interface{}, args ...interface{}) ([]T, err
 	return entities, err
 }
 
+func (d *BaseDAO[T]) CreateBatch(entities []T, batchSize int) error {
+	if len(entities) == 0 {
+		return nil
+	}
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	return d.db.CreateInBatches(&entities, batchSize).Error
+}
+
+func (d *BaseDAO[T]) Upsert(entities []T, opts UpsertOptions) error {
+	if len(entities) == 0 {
+		return nil
+	}
+
+	onConflict := clause.OnConflict{DoNothing: opts.DoNothing}
+	for _, name := range opts.Conflict {
+		onConflict.Columns = append(onConflict.Columns, clause.Column{Name: name})
+	}
+
+	if !opts.DoNothing {
+		if len(opts.Update) == 0 {
+			onConflict.UpdateAll = true
+		} else {
+			columns := opts.Update
+			// 指定更新列时同时刷新自动更新时间
+			if s, err := d.schema(); err == nil {
+				for _, field := range s.Fields {
+					if field.AutoUpdateTime > 0 && !slices.Contains(columns, field.DBName) {
+						columns = append(columns[:len(columns):len(columns)], field.DBName)
+					}
+				}
+			}
+			onConflict.DoUpdates = clause.AssignmentColumns(columns)
+		}
+	}
+
+	// SQLite 的 ON CONFLICT DO UPDATE 必须指定冲突列，默认使用主键
+	if len(onConflict.Columns) == 0 && len(onConflict.DoUpdates) > 0 {
+		s, err := d.schema()
+		if err != nil {
+			return err
+		}
+		for _, field := range s.PrimaryFields {
+			onConflict.Columns = append(onConflict.Columns, clause.Column{Name: field.DBName})
+		}
+	}
+
+	return d.db.Clauses(onConflict).CreateInBatches(&entities, defaultBatchSize).Error
+}
+
+func (d *BaseDAO[T]) UpdateWhere(values map[string]interface{}, conditions interface{}, args ...interface{}) (int64, error) {
+	if conditions == nil {
+		return 0, gorm.ErrMissingWhereClause
+	}
+	result := d.db.Model(new(T)).Where(conditions, args...).Updates(values)
+	return result.RowsAffected, result.Error
+}
+
+func (d *BaseDAO[T]) DeleteWhere(conditions interface{}, args ...interface{}) (int64, error) {
+	if conditions == nil {
+		return 0, gorm.ErrMissingWhereClause
+	}
+	result := d.db.Where(conditions, args...).Delete(new(T))
+	return result.RowsAffected, result.Error
+}
+
+func (d *BaseDAO[T]) FindInBatches(batchSize int, fn func(batch []T) error, conditions interface{}, args ...interface{}) error {
+	if batchSize <= 0 {
+		batchSize = defaultBatchSize
+	}
+	var batch []T
+	return d.where(conditions, args...).FindInBatches(&batch, batchSize, func(_ *gorm.DB, _ int) error {
+		return fn(batch)
+	}).Error
+}
+
+func (d *BaseDAO[T]) Each(fn func(entity *T) error, conditions interface{}, args ...interface{}) error {
+	tx := d.where(conditions, args...)
+	rows, err := tx.Rows()
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entity T
+		if err := tx.ScanRows(rows, &entity); err != nil {
+			return err
+		}
+		if err := fn(&entity); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (d *BaseDAO[T]) FindDeleted(conditions interface{}, args ...interface{}) ([]T, error) {
+	_, deletedAt, err := d.softDelete()
+	if err != nil {
+		return nil, err
+	}
+
+	var entities []T
+	err = d.db.Unscoped().Where(isDeleted(deletedAt)).Where(conditions, args...).Find(&entities).Error
+	return entities, err
+}
+
+func (d *BaseDAO[T]) Restore(id uint) error {
+	s, deletedAt, err := d.softDelete()
+	if err != nil {
+		return err
+	}
+
+	result := d.db.Unscoped().Model(new(T)).
+		Where(clause.Eq{Column: pkColumn(s), Value: id}).
+		Where(isDeleted(deletedAt)).
+		Update(deletedAt.DBName, nil)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+func (d *BaseDAO[T]) ForceDelete(id uint) error {
+	var entity T
+	return d.db.Unscoped().Delete(&entity, id).Error
+}
+
+func (d *BaseDAO[T]) PurgeOlderThan(age time.Duration) (int64, error) {
+	return purgeOlderThan(d.db, new(T), age)
+}
+
 func (d *BaseDAO[T]) DB() *gorm.DB {
 	return d.db.Model(new(T))
 }
+
+func (d *BaseDAO[T]) WithContext(ctx context.Context) IBaseDAO[T] {
+	return &BaseDAO[T]{db: d.db.WithContext(ctx)}
+}
+
+// where 条件为 nil 时查询全部
+func (d *BaseDAO[T]) where(conditions interface{}, args ...interface{}) *gorm.DB {
+	tx := d.db.Model(new(T))
+	if conditions != nil {
+		tx = tx.Where(conditions, args...)
+	}
+	return tx
+}
+
+func (d *BaseDAO[T]) schema() (*schema.Schema, error) {
+	stmt := &gorm.Statement{DB: d.db}
+	if err := stmt.Parse(new(T)); err != nil {
+		return nil, err
+	}
+	return stmt.Schema, nil
+}
+
+// versionField 乐观锁字段：名为 Version 的整数字段
+func versionField(s *schema.Schema) *schema.Field {
+	field := s.LookUpField("Version")
+	if field == nil || field.DBName == "" {
+		return nil
+	}
+	switch field.FieldType.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return field
+	}
+	return nil
+}
+
+func versionAdd(v reflect.Value, n int64) interface{} {
+	if v.CanInt() {
+		return v.Int() + n
+	}
+	return v.Uint() + uint64(n)
+}
+
+// softDelete 解析模型，返回其 gorm.DeletedAt 字段
+func (d *BaseDAO[T]) softDelete() (*schema.Schema, *schema.Field, error) {
+	return softDeleteSchema(d.db, new(T))
+}
+
+// softDeleteSchema 解析模型，返回其 gorm.DeletedAt 字段
+func softDeleteSchema(db *gorm.DB, model interface{}) (*schema.Schema, *schema.Field, error) {
+	stmt := &gorm.Statement{DB: db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, nil, err
+	}
+	for _, field := range stmt.Schema.Fields {
+		if field.FieldType == deletedAtType && field.DBName != "" {
+			return stmt.Schema, field, nil
+		}
+	}
+	return nil, nil, ErrNotSoftDeletable
+}
+
+// purgeOlderThan 按主键分批彻底删除软删除时间早于 age 之前的记录，每批单独提交
+func purgeOlderThan(db *gorm.DB, model interface{}, age time.Duration) (int64, error) {
+	s, deletedAt, err := softDeleteSchema(db, model)
+	if err != nil {
+		return 0, err
+	}
+	if s.PrioritizedPrimaryField == nil {
+		return 0, errors.New("dao: purge requires a primary key")
+	}
+
+	cutoff := time.Now().Add(-age)
+	var total int64
+	for {
+		var ids []interface{}
+		err := db.Unscoped().Model(reflect.New(s.ModelType).Interface()).
+			Where(clause.Lt{Column: fieldColumn(deletedAt), Value: cutoff}).
+			Limit(purgeBatchSize).
+			Pluck(s.PrioritizedPrimaryField.DBName, &ids).Error
+		if err != nil {
+			return total, err
+		}
+		if len(ids) == 0 {
+			return total, nil
+		}
+
+		result := db.Unscoped().Where(clause.IN{Column: pkColumn(s), Values: ids}).Delete(reflect.New(s.ModelType).Interface())
+		if result.Error != nil {
+			return total, result.Error
+		}
+		total += result.RowsAffected
+		if len(ids) < purgeBatchSize {
+			return total, nil
+		}
+	}
+}
+
+// isDeleted 软删除字段非空，即已被删除
+func isDeleted(field *schema.Field) clause.Expression {
+	return clause.Expr{SQL: "? IS NOT NULL", Vars: []interface{}{fieldColumn(field)}}
+}
+
+func fieldColumn(field *schema.Field) clause.Column {
+	return clause.Column{Table: clause.CurrentTable, Name: field.DBName}
+}
+
+func pkColumn(s *schema.Schema) clause.Column {
+	name := clause.PrimaryKey
+	if s.PrioritizedPrimaryField != nil {
+		name = s.PrioritizedPrimaryField.DBName
+	}
+	return clause.Column{Table: clause.CurrentTable, Name: name}
+}
@@ -0,0 +1,508 @@
+package dao
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+type article struct {
+	gorm.Model
+	Title   string
+	Body    string
+	Version uint `gorm:"not null;default:0"`
+}
+
+type member struct {
+	ID        uint
+	Email     string `gorm:"uniqueIndex"`
+	Name      string
+	Score     int
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// tag 没有 gorm.DeletedAt，删除即彻底删除
+type tag struct {
+	ID   uint
+	Name string
+}
+
+func newTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "test.db")), &gorm.Config{Logger: logger.Discard})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := db.AutoMigrate(&article{}, &tag{}, &member{}); err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+func newArticleDAO(t *testing.T) IBaseDAO[article] {
+	t.Helper()
+	return NewBaseDAO[article](newTestDB(t))
+}
+
+// createArticles 插入文章，返回时全部未删除
+func createArticles(t *testing.T, d IBaseDAO[article], titles ...string) []article {
+	t.Helper()
+	articles := make([]article, len(titles))
+	for i, title := range titles {
+		articles[i] = article{Title: title}
+	}
+	if err := d.CreateBatch(articles, 0); err != nil {
+		t.Fatal(err)
+	}
+	return articles
+}
+
+// deleteAt 软删除并把删除时间改为 age 之前
+func deleteAt(t *testing.T, d IBaseDAO[article], id uint, age time.Duration) {
+	t.Helper()
+	if err := d.Delete(id); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.DB().Unscoped().Where("id = ?", id).Update("deleted_at", time.Now().Add(-age)).Error; err != nil {
+		t.Fatal(err)
+	}
+}
+
+func countAll(t *testing.T, d IBaseDAO[article]) int64 {
+	t.Helper()
+	var n int64
+	if err := d.DB().Unscoped().Count(&n).Error; err != nil {
+		t.Fatal(err)
+	}
+	return n
+}
+
+func TestUpdateVersioned(t *testing.T) {
+	d := newArticleDAO(t)
+	a := &article{Title: "v0"}
+	if err := d.Create(a); err != nil {
+		t.Fatal(err)
+	}
+
+	stale := *a
+	a.Title = "v1"
+	if err := d.Update(a); err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if a.Version != 1 {
+		t.Fatalf("Version = %d, want 1", a.Version)
+	}
+
+	stale.Title = "stale"
+	if err := d.Update(&stale); !errors.Is(err, ErrConflict) {
+		t.Fatalf("stale Update = %v, want ErrConflict", err)
+	}
+	if stale.Version != 0 {
+		t.Fatalf("Version after conflict = %d, want restored 0", stale.Version)
+	}
+
+	got, err := d.GetByID(a.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Title != "v1" || got.Version != 1 {
+		t.Fatalf("stored = %q v%d, want v1 v1", got.Title, got.Version)
+	}
+}
+
+func TestUpdateFieldsVersioned(t *testing.T) {
+	d := newArticleDAO(t)
+	a := &article{Title: "title", Body: "body"}
+	if err := d.Create(a); err != nil {
+		t.Fatal(err)
+	}
+
+	a.Title = "new title"
+	a.Body = "ignored"
+	if err := d.UpdateFields(a, "title"); err != nil {
+		t.Fatalf("UpdateFields: %v", err)
+	}
+	got, _ := d.GetByID(a.ID)
+	if got.Title != "new title" || got.Body != "body" || got.Version != 1 {
+		t.Fatalf("stored = %+v, want only title updated and version 1", got)
+	}
+
+	stale := &article{Model: gorm.Model{ID: a.ID}, Title: "stale", Version: 0}
+	if err := d.UpdateFields(stale, "title"); !errors.Is(err, ErrConflict) {
+		t.Fatalf("stale UpdateFields = %v, want ErrConflict", err)
+	}
+}
+
+func TestUpdateFieldsEmptyChecksVersion(t *testing.T) {
+	d := newArticleDAO(t)
+	a := &article{Title: "title"}
+	if err := d.Create(a); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name    string
+		id      uint
+		version uint
+		wantErr error
+	}{
+		{name: "current version", id: a.ID, version: 0},
+		{name: "wrong version", id: a.ID, version: 999, wantErr: ErrConflict},
+		{name: "missing record", id: a.ID + 1, version: 0, wantErr: ErrConflict},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := &article{Model: gorm.Model{ID: tt.id}, Version: tt.version}
+			if err := d.UpdateFields(e); !errors.Is(err, tt.wantErr) {
+				t.Fatalf("UpdateFields() = %v, want %v", err, tt.wantErr)
+			}
+			if e.Version != tt.version {
+				t.Fatalf("Version changed to %d without a write", e.Version)
+			}
+		})
+	}
+
+	if err := d.Delete(a.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := d.UpdateFields(&article{Model: gorm.Model{ID: a.ID}}); !errors.Is(err, ErrConflict) {
+		t.Fatalf("UpdateFields on deleted record = %v, want ErrConflict", err)
+	}
+}
+
+func TestFindDeletedAndRestore(t *testing.T) {
+	d := newArticleDAO(t)
+	a := createArticles(t, d, "kept", "trashed")
+	if err := d.Delete(a[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	trashed, err := d.FindDeleted("title = ?", "trashed")
+	if err != nil || len(trashed) != 1 || trashed[0].ID != a[1].ID {
+		t.Fatalf("FindDeleted = %+v, %v", trashed, err)
+	}
+	if all, err := d.FindDeleted(map[string]interface{}{}); err != nil || len(all) != 1 {
+		t.Fatalf("FindDeleted(all) = %+v, %v; want only the deleted row", all, err)
+	}
+
+	if err := d.Restore(a[1].ID); err != nil {
+		t.Fatalf("Restore: %v", err)
+	}
+	if _, err := d.GetByID(a[1].ID); err != nil {
+		t.Fatalf("GetByID after Restore: %v", err)
+	}
+	if trashed, _ := d.FindDeleted(map[string]interface{}{}); len(trashed) != 0 {
+		t.Fatalf("FindDeleted after Restore = %+v", trashed)
+	}
+
+	for name, id := range map[string]uint{"not deleted": a[0].ID, "missing": a[1].ID + 100} {
+		if err := d.Restore(id); !errors.Is(err, gorm.ErrRecordNotFound) {
+			t.Fatalf("Restore %s = %v, want gorm.ErrRecordNotFound", name, err)
+		}
+	}
+}
+
+func TestForceDelete(t *testing.T) {
+	d := newArticleDAO(t)
+	a := createArticles(t, d, "live", "trashed")
+	if err := d.Delete(a[1].ID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, e := range a {
+		if err := d.ForceDelete(e.ID); err != nil {
+			t.Fatalf("ForceDelete(%d): %v", e.ID, err)
+		}
+	}
+	if n := countAll(t, d); n != 0 {
+		t.Fatalf("%d rows left, want none", n)
+	}
+}
+
+func TestPurgeOlderThan(t *testing.T) {
+	d := newArticleDAO(t)
+	a := createArticles(t, d, "live", "recent", "old", "older")
+	deleteAt(t, d, a[1].ID, time.Hour)
+	deleteAt(t, d, a[2].ID, 48*time.Hour)
+	deleteAt(t, d, a[3].ID, 72*time.Hour)
+
+	n, err := d.PurgeOlderThan(24 * time.Hour)
+	if err != nil || n != 2 {
+		t.Fatalf("PurgeOlderThan = %d, %v; want 2", n, err)
+	}
+	if total := countAll(t, d); total != 2 {
+		t.Fatalf("%d rows left, want live and recent", total)
+	}
+	if trashed, _ := d.FindDeleted(map[string]interface{}{}); len(trashed) != 1 || trashed[0].ID != a[1].ID {
+		t.Fatalf("FindDeleted = %+v, want only the recent row", trashed)
+	}
+}
+
+func TestTrashRequiresDeletedAt(t *testing.T) {
+	d := NewBaseDAO[tag](newTestDB(t))
+	if _, err := d.FindDeleted(map[string]interface{}{}); !errors.Is(err, ErrNotSoftDeletable) {
+		t.Fatalf("FindDeleted = %v, want ErrNotSoftDeletable", err)
+	}
+	if err := d.Restore(1); !errors.Is(err, ErrNotSoftDeletable) {
+		t.Fatalf("Restore = %v, want ErrNotSoftDeletable", err)
+	}
+	if _, err := d.PurgeOlderThan(time.Hour); !errors.Is(err, ErrNotSoftDeletable) {
+		t.Fatalf("PurgeOlderThan = %v, want ErrNotSoftDeletable", err)
+	}
+}
+
+func TestCreateBatch(t *testing.T) {
+	for _, batchSize := range []int{0, 2, 10} {
+		d := newArticleDAO(t)
+		articles := []article{{Title: "a"}, {Title: "b"}, {Title: "c"}}
+		if err := d.CreateBatch(articles, batchSize); err != nil {
+			t.Fatalf("CreateBatch(%d): %v", batchSize, err)
+		}
+		for i, a := range articles {
+			if a.ID == 0 {
+				t.Fatalf("CreateBatch(%d): entity %d has no primary key", batchSize, i)
+			}
+		}
+		if n := countAll(t, d); n != 3 {
+			t.Fatalf("CreateBatch(%d): %d rows, want 3", batchSize, n)
+		}
+	}
+
+	if err := newArticleDAO(t).CreateBatch(nil, 0); err != nil {
+		t.Fatalf("CreateBatch(nil) = %v", err)
+	}
+}
+
+func TestUpsert(t *testing.T) {
+	tests := []struct {
+		name      string
+		opts      UpsertOptions
+		entity    func(existing member) member
+		wantName  string
+		wantScore int
+		touched   bool
+	}{
+		{
+			name:      "update all on primary key",
+			entity:    func(m member) member { return member{ID: m.ID, Email: m.Email, Name: "new", Score: 2} },
+			wantName:  "new",
+			wantScore: 2,
+			touched:   true,
+		},
+		{
+			name:      "update columns on unique key",
+			opts:      UpsertOptions{Conflict: []string{"email"}, Update: []string{"name"}},
+			entity:    func(m member) member { return member{Email: m.Email, Name: "new", Score: 2} },
+			wantName:  "new",
+			wantScore: 1,
+			touched:   true,
+		},
+		{
+			name:      "do nothing",
+			opts:      UpsertOptions{Conflict: []string{"email"}, DoNothing: true},
+			entity:    func(m member) member { return member{Email: m.Email, Name: "new", Score: 2} },
+			wantName:  "old",
+			wantScore: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := NewBaseDAO[member](newTestDB(t))
+			existing := member{Email: "a@example.com", Name: "old", Score: 1}
+			if err := d.Create(&existing); err != nil {
+				t.Fatal(err)
+			}
+			// 保证 updated_at 可区分
+			past := existing.UpdatedAt.Add(-time.Hour)
+			if err := d.DB().Where("id = ?", existing.ID).UpdateColumn("updated_at", past).Error; err != nil {
+				t.Fatal(err)
+			}
+
+			fresh := member{Email: "b@example.com", Name: "fresh"}
+			if err := d.Upsert([]member{tt.entity(existing), fresh}, tt.opts); err != nil {
+				t.Fatalf("Upsert: %v", err)
+			}
+
+			got, err := d.GetByID(existing.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got.Name != tt.wantName || got.Score != tt.wantScore {
+				t.Fatalf("stored = %+v, want name %s, score %d", got, tt.wantName, tt.wantScore)
+			}
+			if touched := got.UpdatedAt.After(past); touched != tt.touched {
+				t.Fatalf("updated_at refreshed = %v, want %v", touched, tt.touched)
+			}
+			if inserted, err := d.Find("email = ?", fresh.Email); err != nil || len(inserted) != 1 {
+				t.Fatalf("new row = %+v, %v; want inserted", inserted, err)
+			}
+		})
+	}
+}
+
+func TestUpdateWhere(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions interface{}
+		args       []interface{}
+		want       int64
+		wantErr    error
+	}{
+		{name: "matching rows", conditions: "title IN ?", args: []interface{}{[]string{"a", "b"}}, want: 2},
+		{name: "no match", conditions: "title = ?", args: []interface{}{"x"}, want: 0},
+		{name: "map conditions", conditions: map[string]interface{}{"title": "c"}, want: 1},
+		{name: "nil conditions", conditions: nil, wantErr: gorm.ErrMissingWhereClause},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newArticleDAO(t)
+			createArticles(t, d, "a", "b", "c")
+
+			n, err := d.UpdateWhere(map[string]interface{}{"body": "updated"}, tt.conditions, tt.args...)
+			if !errors.Is(err, tt.wantErr) || n != tt.want {
+				t.Fatalf("UpdateWhere = %d, %v; want %d, %v", n, err, tt.want, tt.wantErr)
+			}
+			updated, _ := d.Find("body = ?", "updated")
+			if int64(len(updated)) != tt.want {
+				t.Fatalf("%d rows updated, want %d", len(updated), tt.want)
+			}
+		})
+	}
+}
+
+func TestDeleteWhere(t *testing.T) {
+	tests := []struct {
+		name       string
+		conditions interface{}
+		args       []interface{}
+		want       int64
+		wantErr    error
+	}{
+		{name: "matching rows", conditions: "title IN ?", args: []interface{}{[]string{"a", "b"}}, want: 2},
+		{name: "no match", conditions: "title = ?", args: []interface{}{"x"}, want: 0},
+		{name: "nil conditions", conditions: nil, wantErr: gorm.ErrMissingWhereClause},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := newArticleDAO(t)
+			createArticles(t, d, "a", "b", "c")
+
+			n, err := d.DeleteWhere(tt.conditions, tt.args...)
+			if !errors.Is(err, tt.wantErr) || n != tt.want {
+				t.Fatalf("DeleteWhere = %d, %v; want %d, %v", n, err, tt.want, tt.wantErr)
+			}
+			// 软删除模型只标记删除
+			if trashed, _ := d.FindDeleted(map[string]interface{}{}); int64(len(trashed)) != tt.want {
+				t.Fatalf("%d rows soft-deleted, want %d", len(trashed), tt.want)
+			}
+			if total := countAll(t, d); total != 3 {
+				t.Fatalf("%d rows in table, want 3", total)
+			}
+		})
+	}
+
+	tags := NewBaseDAO[tag](newTestDB(t))
+	if err := tags.CreateBatch([]tag{{Name: "a"}, {Name: "b"}}, 0); err != nil {
+		t.Fatal(err)
+	}
+	if n, err := tags.DeleteWhere("name = ?", "a"); err != nil || n != 1 {
+		t.Fatalf("DeleteWhere(tag) = %d, %v", n, err)
+	}
+	var left int64
+	tags.DB().Unscoped().Count(&left)
+	if left != 1 {
+		t.Fatalf("%d tags left, want the row removed", left)
+	}
+}
+
+func TestFindInBatches(t *testing.T) {
+	d := newArticleDAO(t)
+	a := createArticles(t, d, "a", "b", "c", "d", "e")
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name       string
+		batchSize  int
+		conditions interface{}
+		args       []interface{}
+		failAt     int
+		wantSizes  []int
+		wantIDs    []uint
+		wantErr    error
+	}{
+		{name: "all rows", batchSize: 2, wantSizes: []int{2, 2, 1},
+			wantIDs: []uint{a[0].ID, a[1].ID, a[2].ID, a[3].ID, a[4].ID}},
+		{name: "default batch size", wantSizes: []int{5},
+			wantIDs: []uint{a[0].ID, a[1].ID, a[2].ID, a[3].ID, a[4].ID}},
+		{name: "conditions", batchSize: 2, conditions: "title <> ?", args: []interface{}{"c"}, wantSizes: []int{2, 2},
+			wantIDs: []uint{a[0].ID, a[1].ID, a[3].ID, a[4].ID}},
+		{name: "fn error stops", batchSize: 2, failAt: 1, wantSizes: []int{2},
+			wantIDs: []uint{a[0].ID, a[1].ID}, wantErr: stop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var sizes []int
+			var ids []uint
+			err := d.FindInBatches(tt.batchSize, func(batch []article) error {
+				sizes = append(sizes, len(batch))
+				for _, e := range batch {
+					ids = append(ids, e.ID)
+				}
+				if len(sizes) == tt.failAt {
+					return stop
+				}
+				return nil
+			}, tt.conditions, tt.args...)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FindInBatches = %v, want %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(sizes, tt.wantSizes) || !reflect.DeepEqual(ids, tt.wantIDs) {
+				t.Fatalf("batches %v with ids %v, want %v with %v", sizes, ids, tt.wantSizes, tt.wantIDs)
+			}
+		})
+	}
+}
+
+func TestEach(t *testing.T) {
+	d := newArticleDAO(t)
+	createArticles(t, d, "a", "b", "c")
+	if err := d.Delete(1); err != nil {
+		t.Fatal(err)
+	}
+	stop := errors.New("stop")
+
+	tests := []struct {
+		name       string
+		conditions interface{}
+		args       []interface{}
+		failAt     int
+		want       []string
+		wantErr    error
+	}{
+		{name: "all live rows", want: []string{"b", "c"}},
+		{name: "conditions", conditions: "title = ?", args: []interface{}{"c"}, want: []string{"c"}},
+		{name: "fn error stops", failAt: 1, want: []string{"b"}, wantErr: stop},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []string
+			err := d.Each(func(e *article) error {
+				titles = append(titles, e.Title)
+				if len(titles) == tt.failAt {
+					return stop
+				}
+				return nil
+			}, tt.conditions, tt.args...)
+			if !errors.Is(err, tt.wantErr) || !reflect.DeepEqual(titles, tt.want) {
+				t.Fatalf("Each = %v, %v; want %v, %v", titles, err, tt.want, tt.wantErr)
+			}
+		})
+	}
+}
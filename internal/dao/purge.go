@@ -0,0 +1,105 @@
+package dao
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"gorm.io/gorm"
+)
+
+// purgeTask 定时清理时使用的任务名
+const purgeTask = "soft-delete-purge"
+
+// purgeActor 清理产生的审计记录中的操作者
+const purgeActor = "system:purge"
+
+// Purger 按 DATABASE.PURGE.MODELS 定期彻底删除超过保留期的软删除记录
+type Purger struct {
+	db        *gorm.DB
+	retention map[string]time.Duration
+	models    map[string]interface{} // 表名 -> 模型
+	log       *logger.Logger
+}
+
+// NewPurger 构造函数，注册定时清理任务；未开启清理或未启用数据库时返回nil。
+// 可清理的模型取自已启用模块的 Migrations，MODELS 中的表没有对应的软删除模型时返回错误
+func NewPurger(cfg *config.Config, db *gorm.DB, sched *scheduler.Scheduler, modules *module.Manager, log *logger.Logger) (*Purger, error) {
+	if !cfg.Database.Purge.Enabled || db == nil {
+		return nil, nil
+	}
+
+	p, err := newPurger(db, cfg.Database.Purge.Models, modules.Models(), log)
+	if err != nil {
+		return nil, err
+	}
+	if err := sched.Cron(purgeTask, cfg.Database.Purge.Schedule, p.Run); err != nil {
+		return nil, fmt.Errorf("purge: %w", err)
+	}
+	return p, nil
+}
+
+func newPurger(db *gorm.DB, retention map[string]time.Duration, models []interface{}, log *logger.Logger) (*Purger, error) {
+	p := &Purger{
+		db:        db,
+		retention: retention,
+		models:    make(map[string]interface{}),
+		log:       log.WithFields(logger.String("component", "purge")),
+	}
+	for _, model := range models {
+		s, _, err := softDeleteSchema(db, model)
+		if errors.Is(err, ErrNotSoftDeletable) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("purge: %w", err)
+		}
+		p.models[s.Table] = model
+	}
+
+	for table := range retention {
+		if _, ok := p.models[table]; !ok {
+			return nil, fmt.Errorf("purge: no soft-deletable model for table %q in enabled modules", table)
+		}
+	}
+	return p, nil
+}
+
+// Run 依次清理所有配置的模型，单个模型失败不影响其他模型
+func (p *Purger) Run(ctx context.Context) error {
+	if p == nil {
+		return nil
+	}
+	db := p.db.WithContext(audit.ContextWithActor(ctx, purgeActor))
+
+	tables := make([]string, 0, len(p.retention))
+	for table := range p.retention {
+		tables = append(tables, table)
+	}
+	sort.Strings(tables)
+
+	var failed []string
+	for _, table := range tables {
+		n, err := purgeOlderThan(db, p.models[table], p.retention[table])
+		if err != nil {
+			p.log.Error("Purge failed", logger.String("table", table), logger.Int64("purged", n), logger.Error(err))
+			failed = append(failed, table)
+			continue
+		}
+		if n > 0 {
+			p.log.Info("Soft-deleted rows purged", logger.String("table", table), logger.Int64("purged", n))
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("purge: failed tables %v", failed)
+	}
+	return nil
+}
@@ -0,0 +1,80 @@
+package dao
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+func TestPurgerRun(t *testing.T) {
+	db := newTestDB(t)
+	d := NewBaseDAO[article](db)
+	a := createArticles(t, d, "live", "recent", "old")
+	deleteAt(t, d, a[1].ID, time.Hour)
+	deleteAt(t, d, a[2].ID, 48*time.Hour)
+
+	p, err := newPurger(db, map[string]time.Duration{"articles": 24 * time.Hour},
+		[]interface{}{&article{}, &tag{}}, logger.NewNop())
+	if err != nil {
+		t.Fatalf("newPurger: %v", err)
+	}
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if n := countAll(t, d); n != 2 {
+		t.Fatalf("%d rows left, want live and recent", n)
+	}
+}
+
+// 每个 Purger 只清理自己的数据库，与其他应用构建的 DAO 无关
+func TestPurgerUsesOwnDB(t *testing.T) {
+	retention := map[string]time.Duration{"articles": 0}
+
+	db := newTestDB(t)
+	d := NewBaseDAO[article](db)
+	a := createArticles(t, d, "trashed")
+	deleteAt(t, d, a[0].ID, time.Hour)
+
+	other := NewBaseDAO[article](newTestDB(t))
+	b := createArticles(t, other, "trashed")
+	deleteAt(t, other, b[0].ID, time.Hour)
+
+	p, err := newPurger(db, retention, []interface{}{&article{}}, logger.NewNop())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatalf("Run: %v", err)
+	}
+	if n := countAll(t, d); n != 0 {
+		t.Fatalf("own db: %d rows left, want purged", n)
+	}
+	if n := countAll(t, other); n != 1 {
+		t.Fatalf("other db: %d rows left, want untouched", n)
+	}
+}
+
+func TestPurgerUnknownTable(t *testing.T) {
+	db := newTestDB(t)
+	for name, models := range map[string][]interface{}{
+		"not registered":     {&article{}},
+		"not soft-deletable": {&tag{}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := newPurger(db, map[string]time.Duration{"tags": time.Hour}, models, logger.NewNop())
+			if err == nil || !strings.Contains(err.Error(), `"tags"`) {
+				t.Fatalf("newPurger = %v, want error naming the table", err)
+			}
+		})
+	}
+}
+
+func TestNilPurgerRun(t *testing.T) {
+	var p *Purger
+	if err := p.Run(context.Background()); err != nil {
+		t.Fatalf("nil Purger Run = %v", err)
+	}
+}
@@ -0,0 +1,84 @@
+package dao_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/controller"
+	"github.com/mjcode-max/TurboGin/internal/dao"
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/internal/service"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+	"gorm.io/driver/sqlite"
+)
+
+func init() {
+	db.RegisterDriver("sqlite", sqlite.Open)
+}
+
+// 请求经 RequestID 中间件、控制器、Service 到 DAO，GORM 的SQL日志带有同一个 request_id
+func TestSQLLogCarriesRequestID(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	log := &logger.Logger{Logger: zap.New(core)}
+
+	cfg := &config.Config{
+		Server: config.ServerConfig{ShutdownTimeout: time.Second},
+		Database: config.DatabaseConfig{
+			Enabled:  true,
+			Driver:   "sqlite",
+			DSN:      filepath.Join(t.TempDir(), "test.db"),
+			LogLevel: "info",
+		},
+	}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	t.Cleanup(func() { _ = lc.Stop(context.Background()) })
+	gormDB, err := db.NewGormDB(cfg, lc, log)
+	if err != nil {
+		t.Fatalf("NewGormDB: %v", err)
+	}
+	if err := gormDB.AutoMigrate(&model.User{}); err != nil {
+		t.Fatal(err)
+	}
+	user := &model.User{Name: "alice"}
+	if err := gormDB.Create(user).Error; err != nil {
+		t.Fatal(err)
+	}
+
+	ctl := controller.NewUserController(service.NewUserService(dao.NewUserDAO(gormDB), logger.NewNop(), nil))
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	engine.Use(middleware.RequestID())
+	engine.GET("/users/:id", route.Handle(ctl.GetUser))
+
+	logs.TakeAll()
+	req := httptest.NewRequest(http.MethodGet, "/users/1", nil)
+	req.Header.Set(middleware.RequestIDHeader, "req-sql-1")
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Fatalf("status = %d, body: %s", w.Code, w.Body.String())
+	}
+
+	queries := logs.FilterMessage("SQL").All()
+	if len(queries) == 0 {
+		t.Fatal("no SQL logged for the request")
+	}
+	for _, entry := range queries {
+		if id, _ := entry.ContextMap()["request_id"].(string); id != "req-sql-1" {
+			t.Fatalf("SQL log %v has request_id %q, want req-sql-1", entry.ContextMap()["sql"], id)
+		}
+	}
+}
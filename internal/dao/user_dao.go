@@ -1,16 +1,19 @@
 package dao
 
 import (
+	"context"
+
 	"github.com/mjcode-max/TurboGin/internal/model"
 	"gorm.io/gorm"
 )
 
-// IUserDAO 用户数据操作接口
+// IUserDAO 用户数据操作接口，方法接收请求上下文，审计、日志据此关联操作者与请求ID
 type IUserDAO interface {
-	GetByID(id uint) (*model.User, error)
-	Create(user *model.User) error
+	GetByID(ctx context.Context, id uint) (*model.User, error)
+	Create(ctx context.Context, user *model.User) error
+	UpdateFields(ctx context.Context, user *model.User, fields ...string) error
 	// FindByName 扩展自定义查询方法
-	FindByName(name string) ([]model.User, error) // 自定义查询
+	FindByName(ctx context.Context, name string) ([]model.User, error) // 自定义查询
 }
 
 // UserDAO 实现 IUserDAO
@@ -24,10 +27,22 @@ func NewUserDAO(db *gorm.DB) IUserDAO {
 	}
 }
 
+func (u UserDAO) GetByID(ctx context.Context, id uint) (*model.User, error) {
+	return u.WithContext(ctx).GetByID(id)
+}
+
+func (u UserDAO) Create(ctx context.Context, user *model.User) error {
+	return u.WithContext(ctx).Create(user)
+}
+
+func (u UserDAO) UpdateFields(ctx context.Context, user *model.User, fields ...string) error {
+	return u.WithContext(ctx).UpdateFields(user, fields...)
+}
+
 // FindByName 如果不借用IBaseDAO实现访问数据库，可以通过DB()获取db
-func (u UserDAO) FindByName(name string) ([]model.User, error) {
+func (u UserDAO) FindByName(ctx context.Context, name string) ([]model.User, error) {
 	var entities []model.User
-	err := u.DB().Where("name = ?", name).Find(&entities).Error
+	err := u.WithContext(ctx).DB().Where("name = ?", name).Find(&entities).Error
 	if err != nil {
 		return nil, err
 	}
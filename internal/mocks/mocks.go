@@ -0,0 +1,38 @@
+// Code generated by turbo gen mocks. DO NOT EDIT.
+
+// Package mocks 由 turbo gen mocks 根据 internal/dao、internal/service 中的接口生成。
+// 每个替身为接口的每个方法提供一个 Func 字段，未设置时调用会 panic；
+// 调用记录可通过 Calls 查询。
+package mocks
+
+import "sync"
+
+// Call 一次方法调用
+type Call struct {
+	Method string
+	Args   []interface{}
+}
+
+type recorder struct {
+	mu    sync.Mutex
+	calls []Call
+}
+
+func (r *recorder) record(method string, args ...interface{}) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.calls = append(r.calls, Call{Method: method, Args: args})
+}
+
+// Calls 返回指定方法的调用记录，method 为空时返回全部
+func (r *recorder) Calls(method string) []Call {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var calls []Call
+	for _, c := range r.calls {
+		if method == "" || c.Method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
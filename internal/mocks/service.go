@@ -0,0 +1,44 @@
+// Code generated by turbo gen mocks. DO NOT EDIT.
+
+package mocks
+
+import (
+	"context"
+
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/internal/service"
+)
+
+// MockUserService service.IUserService 的测试替身
+type MockUserService struct {
+	recorder
+	GetUserFunc    func(ctx context.Context, id uint) (*model.User, error)
+	CreateUserFunc func(ctx context.Context, user *model.User) error
+	UpdateUserFunc func(ctx context.Context, user *model.User, fields []string) error
+}
+
+var _ service.IUserService = (*MockUserService)(nil)
+
+func (m *MockUserService) GetUser(ctx context.Context, id uint) (*model.User, error) {
+	m.record("GetUser", ctx, id)
+	if m.GetUserFunc == nil {
+		panic("mocks: MockUserService.GetUserFunc is not set")
+	}
+	return m.GetUserFunc(ctx, id)
+}
+
+func (m *MockUserService) CreateUser(ctx context.Context, user *model.User) error {
+	m.record("CreateUser", ctx, user)
+	if m.CreateUserFunc == nil {
+		panic("mocks: MockUserService.CreateUserFunc is not set")
+	}
+	return m.CreateUserFunc(ctx, user)
+}
+
+func (m *MockUserService) UpdateUser(ctx context.Context, user *model.User, fields []string) error {
+	m.record("UpdateUser", ctx, user, fields)
+	if m.UpdateUserFunc == nil {
+		panic("mocks: MockUserService.UpdateUserFunc is not set")
+	}
+	return m.UpdateUserFunc(ctx, user, fields)
+}
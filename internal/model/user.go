@@ -4,4 +4,12 @@ import "gorm.io/gorm"
 
 type User struct {
 	gorm.Model
+	Name string `gorm:"size:64;index" json:"name"`
+	// Version 乐观锁版本号，BaseDAO.Update 时自动校验并自增
+	Version uint `gorm:"not null;default:0" json:"version"`
+}
+
+// AuditEntity 开启数据变更审计（DATABASE.AUDIT.ENABLED）
+func (User) AuditEntity() string {
+	return "user"
 }
@@ -0,0 +1,57 @@
+// Package modules 业务功能模块，每个文件在 init 中通过 module.Register 注册模块工厂，
+// internal/wire 以空导入引入本包
+package modules
+
+import (
+	"github.com/mjcode-max/TurboGin/internal/controller"
+	"github.com/mjcode-max/TurboGin/internal/dao"
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/internal/service"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+)
+
+func init() {
+	module.Register(func() module.Module { return &User{} })
+}
+
+// User 用户注册、查询与更新
+type User struct {
+	module.Base
+
+	userService service.IUserService
+	ctl         *controller.UserController
+}
+
+func (m *User) Name() string { return "user" }
+
+func (m *User) Requires() []string { return []string{"DATABASE"} }
+
+func (m *User) Providers() []module.Provider {
+	return []module.Provider{
+		func(d *module.Deps) error {
+			m.userService = service.NewUserService(dao.NewUserDAO(d.DB), d.Logger, d.Redis)
+			m.ctl = controller.NewUserController(m.userService)
+
+			// 供其他模块使用
+			module.Provide(d, m.userService)
+			return nil
+		},
+	}
+}
+
+func (m *User) Migrations() []interface{} {
+	return []interface{}{&model.User{}}
+}
+
+func (m *User) RegisterRoutes(r *route.Router) {
+	route.POST(r, "/register", m.ctl.CreateUser).Doc("注册用户")
+
+	users := r.Group("/users").Auth()
+	{
+		route.GET(users, "/:id", m.ctl.GetUser).
+			Doc("查询用户", "响应头 ETag 为当前版本号，可用于 PATCH 的 If-Match")
+		route.PATCH(users, "/:id", m.ctl.UpdateUser).
+			Doc("更新用户", "只修改请求体中出现的字段；携带 If-Match 时版本不一致返回412")
+	}
+}
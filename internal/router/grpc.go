@@ -0,0 +1,17 @@
+package router
+
+import (
+	"google.golang.org/grpc"
+)
+
+// RegisterGRPC 注册不属于功能模块的gRPC服务（SERVER.GRPC.ENABLED开启时生效）
+// 功能模块实现 RegisterGRPC(*grpc.Server) 即可自动注册，使用 turbo gen grpc <Name> 生成适配器后例如：
+//
+//	func (m *User) RegisterGRPC(s *grpc.Server) {
+//		userpb.RegisterUserServiceServer(s, grpcsvc.NewUserServer(m.userService))
+//	}
+func RegisterGRPC() func(*grpc.Server) {
+	return func(s *grpc.Server) {
+		// ==================== gRPC服务 ====================
+	}
+}
@@ -1,27 +1,23 @@
 package router
 
 import (
-	"github.com/gin-gonic/gin"
 	"github.com/mjcode-max/TurboGin/internal/controller"
-	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
+	"github.com/mjcode-max/TurboGin/pkg/route"
 )
 
-func RegisterRoutes(ctl *controller.Container, auth *middleware.Auth) func(*gin.Engine) {
-	return func(engine *gin.Engine) {
-		// ==================== 公共路由 ====================
-		publicGroup := engine.Group("/v1")
-		{
-			publicGroup.POST("/register", ctl.User.CreateUser)
-		}
+func RegisterRoutes(ctl *controller.Container, hub *realtime.Hub) func(*route.Router) {
+	return func(r *route.Router) {
+		// 业务路由由功能模块注册（internal/modules），此处仅保留不属于模块的路由，例如：
+		//
+		//	r.GET("/v1/me", ctl.Me.Get).Auth().Doc("当前用户")
 
-		// ==================== 受保护路由 ====================
-		privateGroup := engine.Group("/v1")
-		privateGroup.Use(auth.Middleware())
+		// ==================== 实时推送 ====================
+		// 握手阶段由Hub校验JWT（支持?token=），未开启REALTIME时返回404
+		realtimeGroup := r.Group("/v1/realtime").Tag("realtime")
 		{
-			userRoutes := privateGroup.Group("/users")
-			{
-				userRoutes.GET("/:id", ctl.User.GetUser)
-			}
+			realtimeGroup.GET("/ws", hub.WebSocket()).Doc("WebSocket 订阅")
+			realtimeGroup.GET("/sse", hub.SSE()).Doc("SSE 订阅")
 		}
 	}
 }
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"context"
+
 	"github.com/mjcode-max/TurboGin/internal/dao"
 	"github.com/mjcode-max/TurboGin/internal/model"
 	"github.com/mjcode-max/TurboGin/pkg/logger"
@@ -8,24 +10,30 @@ import (
 )
 
 type IUserService interface {
-	GetUser(id uint) (*model.User, error)
-	CreateUser(user *model.User) error
+	GetUser(ctx context.Context, id uint) (*model.User, error)
+	CreateUser(ctx context.Context, user *model.User) error
+	// UpdateUser 按字段掩码部分更新，版本冲突时返回 dao.ErrConflict
+	UpdateUser(ctx context.Context, user *model.User, fields []string) error
 }
 
 type UserService struct {
 	userDao dao.IUserDAO
 	log     *logger.Logger
-	client  *redis.Client
+	client  *redis.Client // 未启用REDIS时为nil，使用前通过 client.Enabled() 判断
 }
 
 func NewUserService(userDao dao.IUserDAO, log *logger.Logger, client *redis.Client) IUserService {
 	return &UserService{userDao: userDao, log: log, client: client}
 }
 
-func (s *UserService) GetUser(id uint) (*model.User, error) {
-	return s.userDao.GetByID(id)
+func (s *UserService) GetUser(ctx context.Context, id uint) (*model.User, error) {
+	return s.userDao.GetByID(ctx, id)
+}
+
+func (s *UserService) CreateUser(ctx context.Context, user *model.User) error {
+	return s.userDao.Create(ctx, user)
 }
 
-func (s *UserService) CreateUser(user *model.User) error {
-	return s.userDao.Create(user)
+func (s *UserService) UpdateUser(ctx context.Context, user *model.User, fields []string) error {
+	return s.userDao.UpdateFields(ctx, user, fields...)
 }
@@ -0,0 +1,61 @@
+package testkit
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"gorm.io/gorm"
+)
+
+// Factory 按默认值批量构造模型，n为从1开始的序号，便于生成唯一字段
+type Factory[T any] struct {
+	db    *gorm.DB
+	build func(n int) *T
+	n     int
+}
+
+// NewFactory 创建模型工厂
+func NewFactory[T any](kit *Kit, build func(n int) *T) *Factory[T] {
+	return &Factory[T]{db: kit.DB, build: build}
+}
+
+// Build 构造但不入库，overrides 依次修改默认值
+func (f *Factory[T]) Build(overrides ...func(*T)) *T {
+	f.n++
+	entity := f.build(f.n)
+	for _, fn := range overrides {
+		fn(entity)
+	}
+	return entity
+}
+
+// Create 构造并入库
+func (f *Factory[T]) Create(t testing.TB, overrides ...func(*T)) *T {
+	t.Helper()
+	if f.db == nil {
+		t.Fatal("testkit: database disabled")
+	}
+	entity := f.Build(overrides...)
+	if err := f.db.Create(entity).Error; err != nil {
+		t.Fatalf("testkit: create %T: %v", entity, err)
+	}
+	return entity
+}
+
+// CreateN 批量构造并入库
+func (f *Factory[T]) CreateN(t testing.TB, count int, overrides ...func(*T)) []*T {
+	t.Helper()
+	entities := make([]*T, 0, count)
+	for i := 0; i < count; i++ {
+		entities = append(entities, f.Create(t, overrides...))
+	}
+	return entities
+}
+
+// Users 用户工厂，新增模型时在此添加对应的工厂
+func Users(kit *Kit) *Factory[model.User] {
+	return NewFactory(kit, func(n int) *model.User {
+		return &model.User{Name: fmt.Sprintf("user%d", n)}
+	})
+}
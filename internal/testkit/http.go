@@ -0,0 +1,125 @@
+package testkit
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+)
+
+// RequestOption 修改测试请求
+type RequestOption func(*http.Request)
+
+// WithToken 携带JWT
+func WithToken(token string) RequestOption {
+	return WithHeader("Authorization", "Bearer "+token)
+}
+
+// WithHeader 设置请求头
+func WithHeader(key, value string) RequestOption {
+	return func(r *http.Request) { r.Header.Set(key, value) }
+}
+
+// Response 测试响应，Body已完整读取
+type Response struct {
+	*http.Response
+	Body []byte
+}
+
+// Do 向测试服务器发送请求，body非nil时编码为JSON（[]byte和string原样发送）
+func (k *Kit) Do(t testing.TB, method, path string, body interface{}, opts ...RequestOption) *Response {
+	t.Helper()
+
+	var reader io.Reader
+	switch b := body.(type) {
+	case nil:
+	case []byte:
+		reader = bytes.NewReader(b)
+	case string:
+		reader = bytes.NewBufferString(b)
+	default:
+		data, err := json.Marshal(b)
+		if err != nil {
+			t.Fatalf("testkit: encode body: %v", err)
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequest(method, k.Server.URL+path, reader)
+	if err != nil {
+		t.Fatalf("testkit: new request: %v", err)
+	}
+	if reader != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	for _, opt := range opts {
+		opt(req)
+	}
+
+	resp, err := k.Server.Client().Do(req)
+	if err != nil {
+		t.Fatalf("testkit: %s %s: %v", method, path, err)
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("testkit: read body: %v", err)
+	}
+	return &Response{Response: resp, Body: data}
+}
+
+// GET 发送GET请求
+func (k *Kit) GET(t testing.TB, path string, opts ...RequestOption) *Response {
+	t.Helper()
+	return k.Do(t, http.MethodGet, path, nil, opts...)
+}
+
+// POST 发送POST请求
+func (k *Kit) POST(t testing.TB, path string, body interface{}, opts ...RequestOption) *Response {
+	t.Helper()
+	return k.Do(t, http.MethodPost, path, body, opts...)
+}
+
+// PATCH 发送PATCH请求
+func (k *Kit) PATCH(t testing.TB, path string, body interface{}, opts ...RequestOption) *Response {
+	t.Helper()
+	return k.Do(t, http.MethodPatch, path, body, opts...)
+}
+
+// DELETE 发送DELETE请求
+func (k *Kit) DELETE(t testing.TB, path string, opts ...RequestOption) *Response {
+	t.Helper()
+	return k.Do(t, http.MethodDelete, path, nil, opts...)
+}
+
+// AssertStatus 状态码不符时终止测试并输出响应体
+func (r *Response) AssertStatus(t testing.TB, want int) *Response {
+	t.Helper()
+	if r.StatusCode != want {
+		t.Fatalf("testkit: %s %s: status %d, want %d, body: %s",
+			r.Request.Method, r.Request.URL.Path, r.StatusCode, want, r.Body)
+	}
+	return r
+}
+
+// JSON 将响应体解码到v
+func (r *Response) JSON(t testing.TB, v interface{}) {
+	t.Helper()
+	if err := json.Unmarshal(r.Body, v); err != nil {
+		t.Fatalf("testkit: decode body %q: %v", r.Body, err)
+	}
+}
+
+// Data 将成功响应体 {"data": ..., "code": ...} 中的 data 解码到v
+func (r *Response) Data(t testing.TB, v interface{}) {
+	t.Helper()
+	var body struct {
+		Data json.RawMessage `json:"data"`
+	}
+	r.JSON(t, &body)
+	if err := json.Unmarshal(body.Data, v); err != nil {
+		t.Fatalf("testkit: decode data %q: %v", body.Data, err)
+	}
+}
@@ -0,0 +1,192 @@
+// Package testkit 构建完整应用用于集成测试：配置不读取config.yaml，
+// 数据库默认使用测试临时目录中的SQLite库，Redis使用miniredis，HTTP通过httptest提供
+//
+//	func TestGetUser(t *testing.T) {
+//		kit := testkit.New(t)
+//		user := testkit.Users(kit).Create(t)
+//		resp := kit.GET(t, fmt.Sprintf("/v1/users/%d", user.ID), testkit.WithToken(kit.Token(t, user.ID)))
+//		resp.AssertStatus(t, http.StatusOK)
+//	}
+//
+// SQLite驱动依赖cgo，运行测试需要 CGO_ENABLED=1
+package testkit
+
+import (
+	"context"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/wire"
+	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+// Secret 测试使用的JWT密钥
+const Secret = "testkit-secret-key-0123456789abcdef"
+
+func init() {
+	db.RegisterDriver("sqlite", sqlite.Open)
+}
+
+// Option 修改测试配置
+type Option func(*options)
+
+type options struct {
+	configure []func(*config.Config)
+	models    []interface{}
+	redis     bool
+}
+
+// WithConfig 修改配置，例如开启任务队列或实时推送
+func WithConfig(fn func(cfg *config.Config)) Option {
+	return func(o *options) { o.configure = append(o.configure, fn) }
+}
+
+// WithDatabase 使用指定的数据库（例如临时创建的MySQL库）替代SQLite内存库
+func WithDatabase(driver, dsn string) Option {
+	return WithConfig(func(cfg *config.Config) {
+		cfg.Database.Driver = driver
+		cfg.Database.DSN = dsn
+	})
+}
+
+// WithoutDatabase 不启用数据库，依赖数据库的功能模块一并禁用
+func WithoutDatabase() Option {
+	return WithConfig(func(cfg *config.Config) {
+		cfg.Database.Enabled = false
+		cfg.Modules.AutoMigrate = false
+		cfg.Modules.Disabled = append(cfg.Modules.Disabled, module.Requiring("DATABASE")...)
+	})
+}
+
+// WithoutRedis 不启动miniredis，REDIS保持关闭
+func WithoutRedis() Option {
+	return func(o *options) { o.redis = false }
+}
+
+// WithModels 额外需要自动迁移的模型，功能模块声明的模型已在构建时迁移
+func WithModels(models ...interface{}) Option {
+	return func(o *options) { o.models = append(o.models, models...) }
+}
+
+// Kit 运行中的测试应用，测试结束时自动关闭
+type Kit struct {
+	*wire.Components
+
+	// Server 提供公开HTTP接口的测试服务器
+	Server *httptest.Server
+	// Miniredis 内存Redis，可用于快进时间、检查键；WithoutRedis时为nil
+	Miniredis *miniredis.Miniredis
+}
+
+// New 构建并启动应用，失败时终止测试
+func New(t testing.TB, opts ...Option) *Kit {
+	t.Helper()
+
+	o := &options{redis: true}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	cfg := Config(t)
+	kit := &Kit{}
+	if o.redis {
+		kit.Miniredis = miniredis.RunT(t)
+		cfg.Redis.Enabled = true
+		cfg.Redis.Addr = kit.Miniredis.Addr()
+	}
+	for _, fn := range o.configure {
+		fn(cfg)
+	}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("testkit: invalid config: %v", err)
+	}
+
+	components, cleanup, err := wire.InitComponents(cfg)
+	if err != nil {
+		t.Fatalf("testkit: init app: %v", err)
+	}
+	kit.Components = components
+	t.Cleanup(cleanup)
+
+	if kit.DB != nil && len(o.models) > 0 {
+		if err := kit.DB.AutoMigrate(o.models...); err != nil {
+			t.Fatalf("testkit: migrate: %v", err)
+		}
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.Server.StartTimeout)
+	defer cancel()
+	if err := kit.Lifecycle.Start(ctx); err != nil {
+		t.Fatalf("testkit: start: %v", err)
+	}
+
+	kit.Server = httptest.NewServer(kit.Components.Server.Handler())
+	t.Cleanup(kit.Server.Close)
+	return kit
+}
+
+// Config 测试默认配置：临时SQLite库、随机端口、关闭限流，不读取config.yaml和环境变量
+func Config(t testing.TB) *config.Config {
+	t.Helper()
+
+	cfg, err := config.Defaults()
+	if err != nil {
+		t.Fatalf("testkit: config: %v", err)
+	}
+
+	cfg.Env = "test"
+	cfg.Log.Level = "warn"
+	cfg.Log.Output = "stdout"
+	cfg.JWT.Enabled = true
+	cfg.JWT.Secret = Secret
+	cfg.Server.Host = "127.0.0.1"
+	cfg.Server.Port = 0
+	cfg.Server.TrustedProxies = []string{"127.0.0.1"}
+	cfg.Server.DrainPeriod = 0
+	cfg.Server.ShutdownTimeout = 5 * time.Second
+	cfg.Middleware.RateLimit.Enabled = false
+	cfg.Middleware.CORS.AllowOrigins = []string{"*"}
+	cfg.Modules.AutoMigrate = true
+
+	// 测试结束后随临时目录删除；WAL与busy_timeout避免并发请求时的锁冲突
+	cfg.Database.Driver = "sqlite"
+	cfg.Database.DSN = "file:" + filepath.Join(t.TempDir(), "test.db") + "?_journal_mode=WAL&_busy_timeout=5000"
+	cfg.Database.LogLevel = "silent"
+	return cfg
+}
+
+// Token 为指定用户签发JWT
+func (k *Kit) Token(t testing.TB, userID uint, claims ...map[string]interface{}) string {
+	t.Helper()
+	if k.Auth == nil {
+		t.Fatal("testkit: JWT disabled")
+	}
+
+	extra := map[string]interface{}{}
+	for _, c := range claims {
+		for key, v := range c {
+			extra[key] = v
+		}
+	}
+	token, err := k.Auth.GenerateToken(userID, extra)
+	if err != nil {
+		t.Fatalf("testkit: generate token: %v", err)
+	}
+	return token
+}
+
+// Gorm 数据库连接，未启用数据库时终止测试
+func (k *Kit) Gorm(t testing.TB) *gorm.DB {
+	t.Helper()
+	if k.DB == nil {
+		t.Fatal("testkit: database disabled")
+	}
+	return k.DB
+}
@@ -0,0 +1,148 @@
+package testkit_test
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/internal/testkit"
+)
+
+func userPath(id uint) string {
+	return fmt.Sprintf("/v1/users/%d", id)
+}
+
+func getUser(t *testing.T, kit *testkit.Kit, id uint, token string) (*model.User, *testkit.Response) {
+	t.Helper()
+	resp := kit.GET(t, userPath(id), testkit.WithToken(token)).AssertStatus(t, http.StatusOK)
+	var user model.User
+	resp.Data(t, &user)
+	return &user, resp
+}
+
+func TestRegisterAndGetUser(t *testing.T) {
+	kit := testkit.New(t)
+
+	var created model.User
+	kit.POST(t, "/v1/register", map[string]string{"name": "alice"}).
+		AssertStatus(t, http.StatusCreated).
+		Data(t, &created)
+	if created.ID == 0 || created.Name != "alice" {
+		t.Fatalf("created = %+v", created)
+	}
+
+	got, resp := getUser(t, kit, created.ID, kit.Token(t, created.ID))
+	if got.Name != "alice" {
+		t.Fatalf("name = %q, want alice", got.Name)
+	}
+	if etag := resp.Header.Get("ETag"); etag != `"0"` {
+		t.Fatalf("ETag = %s, want \"0\"", etag)
+	}
+}
+
+func TestGetUserRequiresToken(t *testing.T) {
+	kit := testkit.New(t)
+	user := testkit.Users(kit).Create(t)
+
+	kit.GET(t, userPath(user.ID)).AssertStatus(t, http.StatusUnauthorized)
+	kit.GET(t, "/v1/users/abc", testkit.WithToken(kit.Token(t, user.ID))).AssertStatus(t, http.StatusBadRequest)
+}
+
+func TestUpdateUser(t *testing.T) {
+	kit := testkit.New(t)
+	user := testkit.Users(kit).Create(t)
+	token := kit.Token(t, user.ID)
+
+	resp := kit.PATCH(t, userPath(user.ID), map[string]string{"name": "bob"},
+		testkit.WithToken(token), testkit.WithHeader("If-Match", `"0"`)).
+		AssertStatus(t, http.StatusOK)
+	var updated model.User
+	resp.Data(t, &updated)
+	if updated.Name != "bob" || updated.Version != 1 {
+		t.Fatalf("updated = %+v, want name bob, version 1", updated)
+	}
+	if etag := resp.Header.Get("ETag"); etag != `"1"` {
+		t.Fatalf("ETag = %s, want \"1\"", etag)
+	}
+
+	got, resp := getUser(t, kit, user.ID, token)
+	if got.Name != "bob" || got.Version != 1 || resp.Header.Get("ETag") != `"1"` {
+		t.Fatalf("stored = %+v, ETag %s", got, resp.Header.Get("ETag"))
+	}
+}
+
+// 请求体中的 id 不能覆盖路径参数，否则可以修改其他用户
+func TestUpdateUserIgnoresBodyID(t *testing.T) {
+	kit := testkit.New(t)
+	users := testkit.Users(kit)
+	alice := users.Create(t, func(u *model.User) { u.Name = "alice" })
+	bob := users.Create(t, func(u *model.User) { u.Name = "bob" })
+	token := kit.Token(t, alice.ID)
+
+	kit.PATCH(t, userPath(alice.ID), map[string]interface{}{"id": bob.ID, "ID": bob.ID, "name": "mallory"},
+		testkit.WithToken(token)).
+		AssertStatus(t, http.StatusOK)
+
+	if got, _ := getUser(t, kit, bob.ID, token); got.Name != "bob" || got.Version != 0 {
+		t.Fatalf("bob = %+v, want unchanged", got)
+	}
+	if got, _ := getUser(t, kit, alice.ID, token); got.Name != "mallory" {
+		t.Fatalf("alice = %+v, want renamed", got)
+	}
+}
+
+func TestUpdateUserIfMatch(t *testing.T) {
+	kit := testkit.New(t)
+	user := testkit.Users(kit).Create(t, func(u *model.User) { u.Name = "alice" })
+	token := kit.Token(t, user.ID)
+
+	for name, tc := range map[string]struct {
+		ifMatch string
+		body    interface{}
+	}{
+		"stale version": {`"999"`, map[string]string{"name": "bob"}},
+		"empty body":    {`"999"`, map[string]string{}},
+		"weak etag":     {`W/"0"`, map[string]string{"name": "bob"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			kit.PATCH(t, userPath(user.ID), tc.body,
+				testkit.WithToken(token), testkit.WithHeader("If-Match", tc.ifMatch)).
+				AssertStatus(t, http.StatusPreconditionFailed)
+		})
+	}
+
+	got, resp := getUser(t, kit, user.ID, token)
+	if got.Name != "alice" || got.Version != 0 || resp.Header.Get("ETag") != `"0"` {
+		t.Fatalf("stored = %+v, ETag %s, want unchanged", got, resp.Header.Get("ETag"))
+	}
+
+	// 请求体为空且版本一致时不修改数据，ETag 仍为实际版本
+	resp = kit.PATCH(t, userPath(user.ID), map[string]string{},
+		testkit.WithToken(token), testkit.WithHeader("If-Match", `"0"`)).
+		AssertStatus(t, http.StatusOK)
+	if etag := resp.Header.Get("ETag"); etag != `"0"` {
+		t.Fatalf("ETag = %s, want \"0\"", etag)
+	}
+}
+
+// 并行构建的应用各自持有模块实例，路由不会指向其他应用的数据库
+func TestParallelKits(t *testing.T) {
+	for _, name := range []string{"alice", "bob", "carol"} {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			kit := testkit.New(t)
+
+			var created model.User
+			kit.POST(t, "/v1/register", map[string]string{"name": name}).
+				AssertStatus(t, http.StatusCreated).
+				Data(t, &created)
+			if created.ID != 1 {
+				t.Fatalf("ID = %d, want 1 in a fresh database", created.ID)
+			}
+			if got, _ := getUser(t, kit, created.ID, kit.Token(t, created.ID)); got.Name != name {
+				t.Fatalf("name = %q, want %q", got.Name, name)
+			}
+		})
+	}
+}
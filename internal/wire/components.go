@@ -0,0 +1,36 @@
+package wire
+
+import (
+	"github.com/mjcode-max/TurboGin/config"
+	_ "github.com/mjcode-max/TurboGin/internal/modules" // 功能模块在init中自注册
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
+	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/job"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"github.com/mjcode-max/TurboGin/pkg/server"
+	"gorm.io/gorm"
+)
+
+// Components 应用的主要组件，供集成测试直接访问（未启用的组件为nil）
+type Components struct {
+	App       *app.App
+	Config    *config.Config
+	Lifecycle *app.Lifecycle
+	Logger    *logger.Logger
+	DB        *gorm.DB
+	Sources   *db.Sources
+	Redis     *redis.Client
+	Auth      *middleware.Auth
+	Server    *server.Server
+	Jobs      *job.Manager
+	Scheduler *scheduler.Scheduler
+	Hub       *realtime.Hub
+	Auditor   *audit.Auditor
+	Modules   *module.Manager
+}
@@ -0,0 +1,22 @@
+package wire
+
+import (
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/controller"
+	"github.com/mjcode-max/TurboGin/internal/router"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+)
+
+// RouteTable 不连接数据库、Redis 等外部组件，仅登记路由元数据，供 turbo routes 列出路由和生成文档；
+// 与启动时一样校验路由引用的限流策略
+func RouteTable(cfg *config.Config) (*route.Table, error) {
+	r := route.New(nil, middleware.NewRateLimiter(cfg))
+	router.RegisterRoutes(controller.NewContainer(), nil)(r)
+	module.Routes(&cfg.Modules, r)
+	if err := r.Validate(); err != nil {
+		return nil, err
+	}
+	return r.Table(), nil
+}
@@ -8,29 +8,33 @@ import (
 	"github.com/mjcode-max/TurboGin/internal/controller"
 	"github.com/mjcode-max/TurboGin/internal/dao"
 	"github.com/mjcode-max/TurboGin/internal/router"
-	"github.com/mjcode-max/TurboGin/internal/service"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
 	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/job"
+	"github.com/mjcode-max/TurboGin/pkg/lock"
 	"github.com/mjcode-max/TurboGin/pkg/logger"
 	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
 	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"github.com/mjcode-max/TurboGin/pkg/secret"
 	"github.com/mjcode-max/TurboGin/pkg/server"
 
 	"github.com/google/wire"
 )
 
+// 业务功能的DAO、Service、控制器和路由由功能模块构建（internal/modules），不在此声明
 var daoSet = wire.NewSet(
-	dao.NewUserDAO,
-)
-
-var serviceSet = wire.NewSet(
-	service.NewUserService,
+	dao.NewPurger,
 )
 
 var controllerSet = wire.NewSet(
 	controller.NewContainer,
 )
 
-var routerSet = wire.NewSet(router.RegisterRoutes)
+var routerSet = wire.NewSet(router.RegisterRoutes, router.RegisterGRPC)
 
 var middlewareSet = wire.NewSet(
 	middleware.NewCORS,
@@ -38,18 +42,39 @@ var middlewareSet = wire.NewSet(
 	middleware.NewRateLimiter,
 	middleware.NewRequestLog,
 	middleware.NewIPAccess,
+	middleware.NewMetrics,
 )
 
-var systemSet = wire.NewSet(config.Load, db.NewGormDB, logger.New, redis.New, server.New)
+var systemSet = wire.NewSet(app.NewLifecycle, secret.NewWatcher, db.NewGormDB, db.NewSources, audit.New, logger.New, redis.New, job.NewManager, lock.New, scheduler.New, realtime.New, module.New, server.New)
+
+// newApp 汇总需要随应用启停的根组件，确保它们被构建并注册生命周期钩子
+func newApp(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger, _ *server.Server, _ *job.Manager, _ *scheduler.Scheduler, _ *audit.Auditor, _ *dao.Purger) *app.App {
+	return app.New(cfg, lc, log)
+}
+
+func InitApp(opts config.LoadOptions) (*app.App, func(), error) {
+	wire.Build(
+		config.Load,
+		systemSet,
+		middlewareSet,
+		daoSet,
+		controllerSet,
+		routerSet,
+		newApp,
+	)
+	return &app.App{}, nil, nil
+}
 
-func InitApp() (*server.Server, func(), error) {
+// InitComponents 使用给定配置构建应用（不读取config.yaml），返回各组件供集成测试使用
+func InitComponents(cfg *config.Config) (*Components, func(), error) {
 	wire.Build(
 		systemSet,
 		middlewareSet,
 		daoSet,
-		serviceSet,
 		controllerSet,
 		routerSet,
+		newApp,
+		wire.Struct(new(Components), "*"),
 	)
-	return &server.Server{}, nil, nil
+	return &Components{}, nil, nil
 }
@@ -12,56 +12,214 @@ import (
 	"github.com/mjcode-max/TurboGin/internal/controller"
 	"github.com/mjcode-max/TurboGin/internal/dao"
 	"github.com/mjcode-max/TurboGin/internal/router"
-	"github.com/mjcode-max/TurboGin/internal/service"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
 	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/job"
+	"github.com/mjcode-max/TurboGin/pkg/lock"
 	"github.com/mjcode-max/TurboGin/pkg/logger"
 	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
 	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"github.com/mjcode-max/TurboGin/pkg/secret"
 	"github.com/mjcode-max/TurboGin/pkg/server"
 )
 
+import (
+	_ "github.com/mjcode-max/TurboGin/internal/modules"
+)
+
 // Injectors from wire.go:
 
-func InitApp() (*server.Server, func(), error) {
-	configConfig, err := config.Load()
+func InitApp(opts config.LoadOptions) (*app.App, func(), error) {
+	configConfig, err := config.Load(opts)
 	if err != nil {
 		return nil, nil, err
 	}
-	gormDB, err := db.NewGormDB(configConfig)
+	loggerLogger, err := logger.New(configConfig)
 	if err != nil {
 		return nil, nil, err
 	}
-	loggerLogger, err := logger.New(configConfig)
+	lifecycle, cleanup := app.NewLifecycle(configConfig, loggerLogger)
+	gormDB, err := db.NewGormDB(configConfig, lifecycle, loggerLogger)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	auth := middleware.NewAuth(configConfig)
+	watcher := secret.NewWatcher(configConfig, lifecycle, loggerLogger)
+	client, err := redis.New(configConfig, lifecycle, loggerLogger, watcher)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	auth := middleware.NewAuth(configConfig, watcher)
 	cors := middleware.NewCORS(configConfig)
 	rateLimiter := middleware.NewRateLimiter(configConfig)
 	ipAccess := middleware.NewIPAccess(configConfig)
-	iUserDAO := dao.NewUserDAO(gormDB)
-	client, err := redis.New(configConfig, loggerLogger)
+	metrics := middleware.NewMetrics(configConfig)
+	container := controller.NewContainer()
+	sources, err := db.NewSources(configConfig, lifecycle, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	manager, err := job.NewManager(configConfig, lifecycle, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	locker, err := lock.New(configConfig, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	schedulerScheduler, err := scheduler.New(configConfig, lifecycle, locker, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	hub, err := realtime.New(configConfig, lifecycle, auth, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	auditor, err := audit.New(configConfig, gormDB, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	moduleManager, err := module.New(configConfig, lifecycle, loggerLogger, gormDB, sources, client, auth, manager, schedulerScheduler, hub, auditor)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	v := router.RegisterRoutes(container, hub)
+	v2 := router.RegisterGRPC()
+	serverServer, err := server.New(configConfig, lifecycle, gormDB, client, loggerLogger, auth, cors, rateLimiter, ipAccess, metrics, container, moduleManager, v, v2)
 	if err != nil {
+		cleanup()
 		return nil, nil, err
 	}
-	iUserService := service.NewUserService(iUserDAO, loggerLogger, client)
-	container := controller.NewContainer(iUserService)
-	v := router.RegisterRoutes(container, auth)
-	serverServer := server.New(configConfig, gormDB, loggerLogger, auth, cors, rateLimiter, ipAccess, container, v)
-	return serverServer, func() {
+	purger, err := dao.NewPurger(configConfig, gormDB, schedulerScheduler, moduleManager, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	appApp := newApp(configConfig, lifecycle, loggerLogger, serverServer, manager, schedulerScheduler, auditor, purger)
+	return appApp, func() {
+		cleanup()
 	}, nil
 }
 
-// wire.go:
+// InitComponents 使用给定配置构建应用（不读取config.yaml），返回各组件供集成测试使用
+func InitComponents(cfg *config.Config) (*Components, func(), error) {
+	loggerLogger, err := logger.New(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	lifecycle, cleanup := app.NewLifecycle(cfg, loggerLogger)
+	gormDB, err := db.NewGormDB(cfg, lifecycle, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	watcher := secret.NewWatcher(cfg, lifecycle, loggerLogger)
+	client, err := redis.New(cfg, lifecycle, loggerLogger, watcher)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	auth := middleware.NewAuth(cfg, watcher)
+	cors := middleware.NewCORS(cfg)
+	rateLimiter := middleware.NewRateLimiter(cfg)
+	ipAccess := middleware.NewIPAccess(cfg)
+	metrics := middleware.NewMetrics(cfg)
+	container := controller.NewContainer()
+	sources, err := db.NewSources(cfg, lifecycle, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	manager, err := job.NewManager(cfg, lifecycle, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	locker, err := lock.New(cfg, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	schedulerScheduler, err := scheduler.New(cfg, lifecycle, locker, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	hub, err := realtime.New(cfg, lifecycle, auth, client, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	auditor, err := audit.New(cfg, gormDB, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	moduleManager, err := module.New(cfg, lifecycle, loggerLogger, gormDB, sources, client, auth, manager, schedulerScheduler, hub, auditor)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	v := router.RegisterRoutes(container, hub)
+	v2 := router.RegisterGRPC()
+	serverServer, err := server.New(cfg, lifecycle, gormDB, client, loggerLogger, auth, cors, rateLimiter, ipAccess, metrics, container, moduleManager, v, v2)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	purger, err := dao.NewPurger(cfg, gormDB, schedulerScheduler, moduleManager, loggerLogger)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	appApp := newApp(cfg, lifecycle, loggerLogger, serverServer, manager, schedulerScheduler, auditor, purger)
+	components := &Components{
+		App:       appApp,
+		Config:    cfg,
+		Lifecycle: lifecycle,
+		Logger:    loggerLogger,
+		DB:        gormDB,
+		Sources:   sources,
+		Redis:     client,
+		Auth:      auth,
+		Server:    serverServer,
+		Jobs:      manager,
+		Scheduler: schedulerScheduler,
+		Hub:       hub,
+		Auditor:   auditor,
+		Modules:   moduleManager,
+	}
+	return components, func() {
+		cleanup()
+	}, nil
+}
 
-var daoSet = wire.NewSet(dao.NewUserDAO)
+// wire.go:
 
-var serviceSet = wire.NewSet(service.NewUserService)
+// 业务功能的DAO、Service、控制器和路由由功能模块构建（internal/modules），不在此声明
+var daoSet = wire.NewSet(dao.NewPurger)
 
 var controllerSet = wire.NewSet(controller.NewContainer)
 
-var routerSet = wire.NewSet(router.RegisterRoutes)
+var routerSet = wire.NewSet(router.RegisterRoutes, router.RegisterGRPC)
 
-var middlewareSet = wire.NewSet(middleware.NewCORS, middleware.NewAuth, middleware.NewRateLimiter, middleware.NewRequestLog, middleware.NewIPAccess)
+var middlewareSet = wire.NewSet(middleware.NewCORS, middleware.NewAuth, middleware.NewRateLimiter, middleware.NewRequestLog, middleware.NewIPAccess, middleware.NewMetrics)
 
-var systemSet = wire.NewSet(config.Load, db.NewGormDB, logger.New, redis.New, server.New)
+var systemSet = wire.NewSet(app.NewLifecycle, secret.NewWatcher, db.NewGormDB, db.NewSources, audit.New, logger.New, redis.New, job.NewManager, lock.New, scheduler.New, realtime.New, module.New, server.New)
+
+// newApp 汇总需要随应用启停的根组件，确保它们被构建并注册生命周期钩子
+func newApp(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger, _ *server.Server, _ *job.Manager, _ *scheduler.Scheduler, _ *audit.Auditor, _ *dao.Purger) *app.App {
+	return app.New(cfg, lc, log)
+}
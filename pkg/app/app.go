@@ -0,0 +1,82 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// App 应用入口：启动所有组件，等待退出信号或致命错误，然后优雅关闭
+type App struct {
+	cfg *config.Config
+	lc  *Lifecycle
+	log *logger.Logger
+}
+
+// New 构造函数
+func New(cfg *config.Config, lc *Lifecycle, log *logger.Logger) *App {
+	return &App{cfg: cfg, lc: lc, log: log}
+}
+
+// Lifecycle 返回生命周期管理器
+func (a *App) Lifecycle() *Lifecycle {
+	return a.lc
+}
+
+// Run 启动应用并阻塞直到收到SIGINT/SIGTERM或组件上报致命错误
+func (a *App) Run() error {
+	defer func() { _ = a.log.Sync() }()
+
+	startCtx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.StartTimeout)
+	err := a.lc.Start(startCtx)
+	cancel()
+	if err != nil {
+		return fmt.Errorf("app start failed: %w", err)
+	}
+
+	a.log.Info("Application started",
+		logger.String("env", a.cfg.Env),
+		logger.String("version", a.cfg.Version),
+		logger.String("config", strings.Join(a.cfg.Files, ",")))
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
+	defer signal.Stop(quit)
+
+	var runErr error
+	select {
+	case sig := <-quit:
+		a.log.Info("Received signal", logger.String("signal", sig.String()))
+	case runErr = <-a.lc.Failed():
+		a.log.Error("Component failed, shutting down", logger.Error(runErr))
+	}
+
+	return errors.Join(runErr, a.shutdown())
+}
+
+// shutdown 先摘除流量，等待排空期后按逆序关闭组件
+func (a *App) shutdown() error {
+	a.lc.Drain()
+	if d := a.cfg.Server.DrainPeriod; d > 0 {
+		a.log.Info("Draining before shutdown", logger.Duration("period", d))
+		time.Sleep(d)
+	}
+
+	a.log.Info("Shutting down...")
+	ctx, cancel := context.WithTimeout(context.Background(), a.cfg.Server.ShutdownTimeout)
+	defer cancel()
+
+	if err := a.lc.Stop(ctx); err != nil {
+		return fmt.Errorf("app shutdown error: %w", err)
+	}
+	a.log.Info("Application stopped")
+	return nil
+}
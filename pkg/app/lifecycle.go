@@ -0,0 +1,156 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// Hook 组件生命周期钩子
+//
+// OnStart 按注册顺序执行，OnStop 按注册的逆序执行。组件通常在构造函数中注册，
+// wire按依赖顺序调用构造函数，因此被依赖的组件（db、redis）总是晚于依赖方关闭。
+// 未设置OnStart的钩子视为注册时即已启动（例如构造时已建立的连接），Stop时同样会被关闭。
+type Hook struct {
+	Name    string
+	OnStart func(ctx context.Context) error
+	OnStop  func(ctx context.Context) error
+}
+
+// Lifecycle 管理组件的启动与关闭顺序
+type Lifecycle struct {
+	cfg *config.ServerConfig
+	log *logger.Logger
+
+	mu      sync.Mutex
+	hooks   []Hook
+	started []bool // 与hooks一一对应，标记已启动、待关闭的钩子
+	stopped bool
+
+	ready     atomic.Bool
+	failed    chan error
+	failOnce  sync.Once
+	draining  chan struct{}
+	drainOnce sync.Once
+}
+
+// NewLifecycle 构造函数，返回的cleanup会关闭所有已启动的组件（可重复调用）
+func NewLifecycle(cfg *config.Config, log *logger.Logger) (*Lifecycle, func()) {
+	l := &Lifecycle{
+		cfg:      &cfg.Server,
+		log:      log,
+		failed:   make(chan error, 1),
+		draining: make(chan struct{}),
+	}
+
+	cleanup := func() {
+		ctx, cancel := context.WithTimeout(context.Background(), l.cfg.ShutdownTimeout)
+		defer cancel()
+		if err := l.Stop(ctx); err != nil {
+			l.log.Error("Cleanup failed", logger.Error(err))
+		}
+	}
+	return l, cleanup
+}
+
+// Append 注册钩子
+func (l *Lifecycle) Append(h Hook) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.hooks = append(l.hooks, h)
+	l.started = append(l.started, h.OnStart == nil)
+}
+
+// Start 依次执行OnStart，任一失败时逆序关闭已启动的组件并返回错误
+func (l *Lifecycle) Start(ctx context.Context) error {
+	l.mu.Lock()
+	hooks := append([]Hook(nil), l.hooks...)
+	l.mu.Unlock()
+
+	for i, h := range hooks {
+		if h.OnStart == nil {
+			continue
+		}
+
+		begin := time.Now()
+		if err := h.OnStart(ctx); err != nil {
+			startErr := fmt.Errorf("start %s: %w", h.Name, err)
+
+			stopCtx, cancel := context.WithTimeout(context.Background(), l.cfg.ShutdownTimeout)
+			defer cancel()
+			return errors.Join(startErr, l.Stop(stopCtx))
+		}
+		l.log.Debug("Component started", logger.String("component", h.Name), logger.Duration("took", time.Since(begin)))
+
+		l.mu.Lock()
+		l.started[i] = true
+		l.mu.Unlock()
+	}
+
+	l.ready.Store(true)
+	return nil
+}
+
+// Stop 按注册的逆序执行已启动组件的OnStop，所有错误合并返回
+func (l *Lifecycle) Stop(ctx context.Context) error {
+	l.mu.Lock()
+	if l.stopped {
+		l.mu.Unlock()
+		return nil
+	}
+	l.stopped = true
+	hooks := l.hooks
+	started := l.started
+	l.started = make([]bool, len(hooks))
+	l.mu.Unlock()
+
+	l.Drain()
+
+	var errs []error
+	for i := len(hooks) - 1; i >= 0; i-- {
+		h := hooks[i]
+		if !started[i] || h.OnStop == nil {
+			continue
+		}
+		if err := h.OnStop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("stop %s: %w", h.Name, err))
+			continue
+		}
+		l.log.Debug("Component stopped", logger.String("component", h.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// Fail 组件运行期间发生致命错误时调用，触发整个应用关闭
+func (l *Lifecycle) Fail(err error) {
+	l.failOnce.Do(func() { l.failed <- err })
+}
+
+// Failed 返回组件上报的致命错误
+func (l *Lifecycle) Failed() <-chan error {
+	return l.failed
+}
+
+// Ready 所有组件启动完成且尚未开始关闭
+func (l *Lifecycle) Ready() bool {
+	return l.ready.Load()
+}
+
+// Drain 标记为未就绪，使负载均衡摘除本实例
+func (l *Lifecycle) Drain() {
+	l.ready.Store(false)
+	l.drainOnce.Do(func() { close(l.draining) })
+}
+
+// Draining 返回在开始关闭时关闭的channel，供WebSocket、SSE等长连接组件提前断开客户端，
+// 避免HTTP服务关闭时一直等待这些连接
+func (l *Lifecycle) Draining() <-chan struct{} {
+	return l.draining
+}
@@ -0,0 +1,113 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+func newLifecycle() *Lifecycle {
+	lc, _ := NewLifecycle(&config.Config{Server: config.ServerConfig{ShutdownTimeout: time.Second}}, logger.NewNop())
+	return lc
+}
+
+// recorder 记录钩子的执行顺序
+type recorder struct {
+	events []string
+}
+
+func (r *recorder) hook(name string, withStart bool, startErr error) Hook {
+	h := Hook{
+		Name: name,
+		OnStop: func(context.Context) error {
+			r.events = append(r.events, "stop "+name)
+			return nil
+		},
+	}
+	if withStart {
+		h.OnStart = func(context.Context) error {
+			r.events = append(r.events, "start "+name)
+			return startErr
+		}
+	}
+	return h
+}
+
+func TestStopInReverseRegistrationOrder(t *testing.T) {
+	lc := newLifecycle()
+	r := &recorder{}
+	lc.Append(r.hook("db", false, nil))
+	lc.Append(r.hook("jobs", true, nil))
+	lc.Append(r.hook("redis", false, nil))
+	lc.Append(r.hook("server", true, nil))
+
+	if err := lc.Start(context.Background()); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if !lc.Ready() {
+		t.Fatal("not ready after Start")
+	}
+	if err := lc.Stop(context.Background()); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+
+	want := []string{
+		"start jobs", "start server",
+		"stop server", "stop redis", "stop jobs", "stop db",
+	}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+	if lc.Ready() {
+		t.Fatal("still ready after Stop")
+	}
+}
+
+func TestStartFailureStopsStartedHooks(t *testing.T) {
+	lc := newLifecycle()
+	r := &recorder{}
+	boom := errors.New("boom")
+	lc.Append(r.hook("db", false, nil))
+	lc.Append(r.hook("jobs", true, nil))
+	lc.Append(r.hook("server", true, boom))
+	lc.Append(r.hook("redis", false, nil))
+	lc.Append(r.hook("grpc", true, nil))
+
+	if err := lc.Start(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("Start = %v, want %v", err, boom)
+	}
+
+	// server启动失败，未启动的grpc不关闭；构造时已就绪的redis仍需关闭
+	want := []string{
+		"start jobs", "start server",
+		"stop redis", "stop jobs", "stop db",
+	}
+	if !reflect.DeepEqual(r.events, want) {
+		t.Fatalf("events = %v, want %v", r.events, want)
+	}
+}
+
+func TestStopOnce(t *testing.T) {
+	lc := newLifecycle()
+	r := &recorder{}
+	lc.Append(r.hook("db", false, nil))
+
+	for i := 0; i < 2; i++ {
+		if err := lc.Stop(context.Background()); err != nil {
+			t.Fatalf("Stop: %v", err)
+		}
+	}
+	if len(r.events) != 1 {
+		t.Fatalf("events = %v, want a single stop", r.events)
+	}
+	select {
+	case <-lc.Draining():
+	default:
+		t.Fatal("Draining() not closed after Stop")
+	}
+}
@@ -0,0 +1,197 @@
+package audit
+
+import (
+	"context"
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"gorm.io/gorm"
+)
+
+// 审计动作
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+)
+
+// 审计记录输出方式
+const (
+	SinkTable  = "table"
+	SinkLogger = "logger"
+)
+
+// ErrNotQueryable SINK为logger时无法查询历史
+var ErrNotQueryable = errors.New("audit: history is only available with table sink")
+
+// Auditable 实现该接口的模型记录全部字段的变更（audit:"-"的字段除外）；
+// 未实现该接口的模型只记录带有 audit:"true" 或 audit:"redact" 标签的字段
+type Auditable interface {
+	// AuditEntity 审计记录中的实体名
+	AuditEntity() string
+}
+
+// Record 一条审计记录
+type Record struct {
+	ID        uint      `gorm:"primarykey" json:"id"`
+	Entity    string    `gorm:"size:64;index:idx_audit_entity,priority:1;not null" json:"entity"`
+	EntityID  string    `gorm:"size:64;index:idx_audit_entity,priority:2" json:"entity_id"`
+	Action    string    `gorm:"size:16;not null" json:"action"`
+	Actor     string    `gorm:"size:64;index" json:"actor"`
+	RequestID string    `gorm:"size:64" json:"request_id"`
+	Changes   Changes   `gorm:"type:text" json:"changes"`
+	CreatedAt time.Time `gorm:"index" json:"created_at"`
+}
+
+// Change 单个字段的变更，创建时只有New，删除时只有Old
+type Change struct {
+	Old interface{} `json:"old,omitempty"`
+	New interface{} `json:"new,omitempty"`
+}
+
+// Changes 字段名(列名)到变更的映射，以JSON存储
+type Changes map[string]Change
+
+// Value 实现driver.Valuer
+func (c Changes) Value() (driver.Value, error) {
+	if c == nil {
+		return "{}", nil
+	}
+	data, err := json.Marshal(c)
+	return string(data), err
+}
+
+// Scan 实现sql.Scanner
+func (c *Changes) Scan(value interface{}) error {
+	switch v := value.(type) {
+	case nil:
+		*c = nil
+		return nil
+	case []byte:
+		return json.Unmarshal(v, c)
+	case string:
+		return json.Unmarshal([]byte(v), c)
+	default:
+		return fmt.Errorf("audit: unsupported changes type %T", value)
+	}
+}
+
+type actorKey struct{}
+
+// ContextWithActor 指定操作者（后台任务、定时任务等没有JWT的场景）
+func ContextWithActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, actorKey{}, actor)
+}
+
+// ActorFromContext 依次从显式指定的操作者、JWT claims中的userID解析操作者
+func ActorFromContext(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	if actor, ok := ctx.Value(actorKey{}).(string); ok {
+		return actor
+	}
+	if claims, ok := middleware.ClaimsFromContext(ctx); ok {
+		if uid, ok := claims["userID"]; ok {
+			return fmt.Sprint(uid)
+		}
+	}
+	return ""
+}
+
+// Auditor GORM审计插件
+type Auditor struct {
+	cfg *config.AuditConfig
+	db  *gorm.DB
+	log *logger.Logger
+}
+
+// New 构造函数，注册GORM插件；未开启审计或未启用数据库时返回nil
+func New(cfg *config.Config, db *gorm.DB, log *logger.Logger) (*Auditor, error) {
+	if !cfg.Database.Audit.Enabled || db == nil {
+		return nil, nil
+	}
+
+	a := &Auditor{
+		cfg: &cfg.Database.Audit,
+		db:  db,
+		log: log.WithFields(logger.String("component", "audit")),
+	}
+
+	switch a.cfg.Sink {
+	case SinkTable:
+		if a.cfg.AutoMigrate {
+			if err := db.Table(a.cfg.Table).AutoMigrate(&Record{}); err != nil {
+				return nil, fmt.Errorf("audit: migrate %s: %w", a.cfg.Table, err)
+			}
+		}
+	case SinkLogger:
+		if a.cfg.LogFile != "" {
+			a.log = logger.NewFile(&cfg.Log, a.cfg.LogFile)
+		}
+	default:
+		return nil, fmt.Errorf("audit: unsupported sink %q", a.cfg.Sink)
+	}
+
+	if err := db.Use(a); err != nil {
+		return nil, fmt.Errorf("audit: register plugin: %w", err)
+	}
+	return a, nil
+}
+
+// HistoryQuery 历史查询条件
+type HistoryQuery struct {
+	Action string    // 为空时不限
+	Since  time.Time // 为零值时不限
+	Limit  int       // 默认100
+	Offset int
+}
+
+// History 查询某条记录的变更历史，按时间倒序
+func (a *Auditor) History(ctx context.Context, entity string, id interface{}, q HistoryQuery) ([]Record, error) {
+	return a.query(ctx, q, "entity = ? AND entity_id = ?", entity, fmt.Sprint(id))
+}
+
+// HistoryOf 根据模型解析实体名后查询历史，例如 HistoryOf(ctx, &model.User{}, 1, q)
+func (a *Auditor) HistoryOf(ctx context.Context, model interface{}, id interface{}, q HistoryQuery) ([]Record, error) {
+	if a == nil {
+		return nil, ErrNotQueryable
+	}
+	stmt := &gorm.Statement{DB: a.db}
+	if err := stmt.Parse(model); err != nil {
+		return nil, err
+	}
+	return a.History(ctx, entityName(stmt.Schema), id, q)
+}
+
+// ByActor 查询某个操作者的变更记录，按时间倒序
+func (a *Auditor) ByActor(ctx context.Context, actor string, q HistoryQuery) ([]Record, error) {
+	return a.query(ctx, q, "actor = ?", actor)
+}
+
+func (a *Auditor) query(ctx context.Context, q HistoryQuery, cond string, args ...interface{}) ([]Record, error) {
+	if a == nil || a.cfg.Sink != SinkTable {
+		return nil, ErrNotQueryable
+	}
+	if q.Limit <= 0 {
+		q.Limit = 100
+	}
+
+	tx := a.db.WithContext(ctx).Table(a.cfg.Table).Where(cond, args...)
+	if q.Action != "" {
+		tx = tx.Where("action = ?", q.Action)
+	}
+	if !q.Since.IsZero() {
+		tx = tx.Where("created_at >= ?", q.Since)
+	}
+
+	var records []Record
+	err := tx.Order("id DESC").Limit(q.Limit).Offset(q.Offset).Find(&records).Error
+	return records, err
+}
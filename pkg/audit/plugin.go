@@ -0,0 +1,409 @@
+package audit
+
+import (
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"gorm.io/gorm/schema"
+)
+
+const beforeKey = "audit:before"
+
+// ErrTooManyRows 批量更新、删除影响的行数超过 MAX_ROWS，语句不执行，避免部分行没有审计记录
+var ErrTooManyRows = errors.New("audit: statement affects more rows than DATABASE.AUDIT.MAX_ROWS")
+
+// spec 模型的审计规则（按schema缓存）
+type spec struct {
+	entity  string
+	fields  []*schema.Field
+	redact  map[string]bool
+	enabled bool
+}
+
+var specs sync.Map // *schema.Schema -> *spec
+
+// Name 实现gorm.Plugin
+func (a *Auditor) Name() string {
+	return "audit"
+}
+
+// Initialize 实现gorm.Plugin，在事务提交前写入审计记录，审计失败时整个操作回滚
+func (a *Auditor) Initialize(db *gorm.DB) error {
+	cb := db.Callback()
+	if err := cb.Create().After("gorm:create").Register("audit:after_create", a.afterCreate); err != nil {
+		return err
+	}
+	if err := cb.Update().Before("gorm:update").Register("audit:before_update", a.snapshot); err != nil {
+		return err
+	}
+	if err := cb.Update().After("gorm:update").Register("audit:after_update", a.afterUpdate); err != nil {
+		return err
+	}
+	if err := cb.Delete().Before("gorm:delete").Register("audit:before_delete", a.snapshot); err != nil {
+		return err
+	}
+	return cb.Delete().After("gorm:delete").Register("audit:after_delete", a.afterDelete)
+}
+
+// specOf 解析模型是否开启审计及需要记录的字段
+func specOf(s *schema.Schema) *spec {
+	if v, ok := specs.Load(s); ok {
+		return v.(*spec)
+	}
+
+	sp := &spec{entity: entityName(s), redact: map[string]bool{}}
+	_, sp.enabled = reflect.New(s.ModelType).Interface().(Auditable)
+
+	for _, f := range s.Fields {
+		if f.DBName == "" {
+			continue
+		}
+		tag := strings.TrimSpace(f.Tag.Get("audit"))
+		switch {
+		case tag == "-":
+			continue
+		case tag == "true" || tag == "redact":
+			sp.enabled = true
+		case tag != "":
+			continue
+		case !implementsAuditable(s) || f.AutoCreateTime > 0 || f.AutoUpdateTime > 0:
+			// 未实现接口时只记录打了标签的字段；时间戳字段每次都会变化，不记录
+			continue
+		}
+		if tag == "redact" {
+			sp.redact[f.DBName] = true
+		}
+		sp.fields = append(sp.fields, f)
+	}
+
+	specs.Store(s, sp)
+	return sp
+}
+
+func implementsAuditable(s *schema.Schema) bool {
+	_, ok := reflect.New(s.ModelType).Interface().(Auditable)
+	return ok
+}
+
+// entityName 实体名优先取AuditEntity()，否则使用表名
+func entityName(s *schema.Schema) string {
+	if a, ok := reflect.New(s.ModelType).Interface().(Auditable); ok {
+		return a.AuditEntity()
+	}
+	return s.Table
+}
+
+// tracked 判断当前语句是否需要审计
+func (a *Auditor) tracked(db *gorm.DB) (*spec, bool) {
+	stmt := db.Statement
+	if db.Error != nil || stmt.Schema == nil || stmt.Table == a.cfg.Table {
+		return nil, false
+	}
+	sp := specOf(stmt.Schema)
+	return sp, sp.enabled && len(sp.fields) > 0
+}
+
+// snapshot 更新/删除前读取受影响行的当前值
+func (a *Auditor) snapshot(db *gorm.DB) {
+	if _, ok := a.tracked(db); !ok {
+		return
+	}
+
+	rows, err := a.load(db, func(tx *gorm.DB) *gorm.DB {
+		if where, ok := db.Statement.Clauses["WHERE"]; ok {
+			if expr, ok := where.Expression.(clause.Where); ok && len(expr.Exprs) > 0 {
+				tx = tx.Clauses(clause.Where{Exprs: expr.Exprs})
+			}
+		}
+		if conds := primaryKeyConds(db.Statement); len(conds) > 0 {
+			tx = tx.Clauses(clause.Where{Exprs: conds})
+		}
+		return tx
+	})
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("audit: load before values: %w", err))
+		return
+	}
+	if a.cfg.MaxRows > 0 && len(rows) > a.cfg.MaxRows {
+		_ = db.AddError(fmt.Errorf("%w (%d)", ErrTooManyRows, a.cfg.MaxRows))
+		return
+	}
+	db.InstanceSet(beforeKey, rows)
+}
+
+// afterUpdate 对比更新前后的值
+func (a *Auditor) afterUpdate(db *gorm.DB) {
+	sp, ok := a.tracked(db)
+	if !ok || db.RowsAffected == 0 {
+		return
+	}
+	before := beforeRows(db)
+	if len(before) == 0 {
+		return
+	}
+
+	after, err := a.load(db, func(tx *gorm.DB) *gorm.DB {
+		return tx.Where(rowsKeyCond(db.Statement.Schema, before))
+	})
+	if err != nil {
+		_ = db.AddError(fmt.Errorf("audit: load after values: %w", err))
+		return
+	}
+	afterByKey := make(map[string]map[string]interface{}, len(after))
+	for _, row := range after {
+		afterByKey[rowKey(db.Statement.Schema, row)] = row
+	}
+
+	records := make([]Record, 0, len(before))
+	for _, old := range before {
+		key := rowKey(db.Statement.Schema, old)
+		changes := diff(sp, old, afterByKey[key])
+		if len(changes) > 0 {
+			records = append(records, a.newRecord(db, sp, ActionUpdate, key, changes))
+		}
+	}
+	a.write(db, records)
+}
+
+// afterDelete 记录被删除行的原值
+func (a *Auditor) afterDelete(db *gorm.DB) {
+	sp, ok := a.tracked(db)
+	if !ok || db.RowsAffected == 0 {
+		return
+	}
+
+	before := beforeRows(db)
+	records := make([]Record, 0, len(before))
+	for _, old := range before {
+		records = append(records, a.newRecord(db, sp, ActionDelete, rowKey(db.Statement.Schema, old), diff(sp, old, nil)))
+	}
+	a.write(db, records)
+}
+
+// afterCreate 记录新建行的值
+func (a *Auditor) afterCreate(db *gorm.DB) {
+	sp, ok := a.tracked(db)
+	if !ok || db.RowsAffected == 0 {
+		return
+	}
+
+	stmt := db.Statement
+	var values []reflect.Value
+	switch rv := reflect.Indirect(stmt.ReflectValue); rv.Kind() {
+	case reflect.Struct:
+		values = append(values, rv)
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < rv.Len(); i++ {
+			values = append(values, reflect.Indirect(rv.Index(i)))
+		}
+	default:
+		return
+	}
+
+	records := make([]Record, 0, len(values))
+	for _, rv := range values {
+		row := make(map[string]interface{}, len(sp.fields))
+		for _, f := range stmt.Schema.Fields {
+			if f.DBName != "" {
+				v, _ := f.ValueOf(stmt.Context, rv)
+				row[f.DBName] = v
+			}
+		}
+		records = append(records, a.newRecord(db, sp, ActionCreate, rowKey(stmt.Schema, row), diff(sp, nil, row)))
+	}
+	a.write(db, records)
+}
+
+// load 在当前连接（含事务）上按条件读取行，最多多读一行用于判断是否超过 MAX_ROWS
+func (a *Auditor) load(db *gorm.DB, scope func(*gorm.DB) *gorm.DB) ([]map[string]interface{}, error) {
+	stmt := db.Statement
+	tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).
+		Model(reflect.New(stmt.Schema.ModelType).Interface()).
+		Table(stmt.Table)
+	if stmt.Unscoped {
+		tx = tx.Unscoped()
+	}
+
+	tx = scope(tx)
+	if a.cfg.MaxRows > 0 {
+		tx = tx.Limit(a.cfg.MaxRows + 1)
+	}
+	var rows []map[string]interface{}
+	err := tx.Find(&rows).Error
+	return rows, err
+}
+
+// write 输出审计记录，写入审计表时与业务操作处于同一事务
+func (a *Auditor) write(db *gorm.DB, records []Record) {
+	if len(records) == 0 {
+		return
+	}
+
+	if a.cfg.Sink == SinkTable {
+		tx := db.Session(&gorm.Session{NewDB: true, SkipHooks: true}).Table(a.cfg.Table)
+		if err := tx.Create(&records).Error; err != nil {
+			_ = db.AddError(fmt.Errorf("audit: write records: %w", err))
+		}
+		return
+	}
+
+	for _, r := range records {
+		a.log.Info("Data changed",
+			logger.String("entity", r.Entity),
+			logger.String("entity_id", r.EntityID),
+			logger.String("action", r.Action),
+			logger.String("actor", r.Actor),
+			logger.String("request_id", r.RequestID),
+			logger.Any("changes", r.Changes),
+		)
+	}
+}
+
+func (a *Auditor) newRecord(db *gorm.DB, sp *spec, action, id string, changes Changes) Record {
+	ctx := db.Statement.Context
+	return Record{
+		Entity:    sp.entity,
+		EntityID:  id,
+		Action:    action,
+		Actor:     ActorFromContext(ctx),
+		RequestID: logger.RequestIDFromContext(ctx),
+		Changes:   changes,
+		CreatedAt: time.Now(),
+	}
+}
+
+// diff 比较记录的字段，old或new为nil时表示创建或删除
+func diff(sp *spec, old, new map[string]interface{}) Changes {
+	changes := Changes{}
+	for _, f := range sp.fields {
+		var before, after interface{}
+		if old != nil {
+			before = normalize(old[f.DBName])
+		}
+		if new != nil {
+			after = normalize(new[f.DBName])
+		}
+		if old != nil && new != nil && reflect.DeepEqual(before, after) {
+			continue
+		}
+		if before == nil && after == nil {
+			continue
+		}
+		if sp.redact[f.DBName] {
+			if before != nil {
+				before = config.RedactedValue
+			}
+			if after != nil {
+				after = config.RedactedValue
+			}
+		}
+		changes[f.DBName] = Change{Old: before, New: after}
+	}
+	return changes
+}
+
+// normalize 统一驱动返回值与模型字段值的表示，便于比较和序列化
+func normalize(v interface{}) interface{} {
+	switch x := v.(type) {
+	case nil:
+		return nil
+	case []byte:
+		return string(x)
+	case time.Time:
+		if x.IsZero() {
+			return nil
+		}
+		return x.UTC().Format(time.RFC3339Nano)
+	case driver.Valuer:
+		val, err := x.Value()
+		if err != nil {
+			return fmt.Sprint(x)
+		}
+		return normalize(val)
+	}
+
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.Ptr:
+		if rv.IsNil() {
+			return nil
+		}
+		return normalize(rv.Elem().Interface())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return rv.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return int64(rv.Uint())
+	case reflect.Float32:
+		return rv.Float()
+	case reflect.String:
+		return rv.String()
+	}
+	return v
+}
+
+// primaryKeyConds 从模型值中提取非零主键作为条件
+func primaryKeyConds(stmt *gorm.Statement) []clause.Expression {
+	rv := reflect.Indirect(stmt.ReflectValue)
+	if rv.Kind() != reflect.Struct || len(stmt.Schema.PrimaryFields) == 0 {
+		return nil
+	}
+
+	conds := make([]clause.Expression, 0, len(stmt.Schema.PrimaryFields))
+	for _, f := range stmt.Schema.PrimaryFields {
+		v, zero := f.ValueOf(stmt.Context, rv)
+		if zero {
+			return nil
+		}
+		conds = append(conds, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: v})
+	}
+	return conds
+}
+
+// rowsKeyCond 按主键重新定位更新前读取的行
+func rowsKeyCond(s *schema.Schema, rows []map[string]interface{}) clause.Expression {
+	if len(s.PrimaryFields) == 1 {
+		col := s.PrimaryFields[0].DBName
+		values := make([]interface{}, 0, len(rows))
+		for _, row := range rows {
+			values = append(values, row[col])
+		}
+		return clause.IN{Column: clause.Column{Table: clause.CurrentTable, Name: col}, Values: values}
+	}
+
+	ors := make([]clause.Expression, 0, len(rows))
+	for _, row := range rows {
+		eqs := make([]clause.Expression, 0, len(s.PrimaryFields))
+		for _, f := range s.PrimaryFields {
+			eqs = append(eqs, clause.Eq{Column: clause.Column{Table: clause.CurrentTable, Name: f.DBName}, Value: row[f.DBName]})
+		}
+		ors = append(ors, clause.And(eqs...))
+	}
+	return clause.Or(ors...)
+}
+
+// rowKey 主键的字符串形式，联合主键以逗号连接
+func rowKey(s *schema.Schema, row map[string]interface{}) string {
+	parts := make([]string, 0, len(s.PrimaryFields))
+	for _, f := range s.PrimaryFields {
+		parts = append(parts, fmt.Sprint(normalize(row[f.DBName])))
+	}
+	return strings.Join(parts, ",")
+}
+
+func beforeRows(db *gorm.DB) []map[string]interface{} {
+	v, ok := db.InstanceGet(beforeKey)
+	if !ok {
+		return nil
+	}
+	rows, _ := v.([]map[string]interface{})
+	return rows
+}
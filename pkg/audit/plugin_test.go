@@ -0,0 +1,200 @@
+package audit
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+type account struct {
+	ID        uint
+	Name      string
+	Password  string `audit:"redact"`
+	Token     string `audit:"-"`
+	UpdatedAt time.Time
+}
+
+func (account) AuditEntity() string { return "account" }
+
+// note 未实现 Auditable 且没有审计标签，不记录
+type note struct {
+	ID   uint
+	Body string
+}
+
+func newAuditor(t *testing.T, maxRows int) (*Auditor, *gorm.DB) {
+	t.Helper()
+	db, err := gorm.Open(sqlite.Open(filepath.Join(t.TempDir(), "audit.db")), &gorm.Config{
+		Logger: gormlogger.Default.LogMode(gormlogger.Silent),
+	})
+	if err != nil {
+		t.Fatalf("open sqlite: %v", err)
+	}
+	if err := db.AutoMigrate(&account{}, &note{}); err != nil {
+		t.Fatalf("migrate: %v", err)
+	}
+
+	cfg := &config.Config{Database: config.DatabaseConfig{Audit: config.AuditConfig{
+		Enabled:     true,
+		Sink:        SinkTable,
+		Table:       "audit_logs",
+		AutoMigrate: true,
+		MaxRows:     maxRows,
+	}}}
+	a, err := New(cfg, db, logger.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return a, db
+}
+
+func history(t *testing.T, a *Auditor, id uint) []Record {
+	t.Helper()
+	records, err := a.HistoryOf(context.Background(), &account{}, id, HistoryQuery{})
+	if err != nil {
+		t.Fatalf("HistoryOf: %v", err)
+	}
+	return records
+}
+
+func TestAuditCreateUpdateDelete(t *testing.T) {
+	a, db := newAuditor(t, 100)
+
+	acc := &account{Name: "alice", Password: "secret", Token: "t1"}
+	if err := db.Create(acc).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	records := history(t, a, acc.ID)
+	if len(records) != 1 || records[0].Action != ActionCreate {
+		t.Fatalf("records after create = %+v", records)
+	}
+	created := records[0].Changes
+	if created["name"].New != "alice" || created["name"].Old != nil {
+		t.Fatalf("create name change = %+v", created["name"])
+	}
+	if created["password"].New != config.RedactedValue {
+		t.Fatalf("password not redacted: %+v", created["password"])
+	}
+	if _, ok := created["token"]; ok {
+		t.Fatal(`field tagged audit:"-" was recorded`)
+	}
+	if _, ok := created["updated_at"]; ok {
+		t.Fatal("auto update time was recorded")
+	}
+
+	if err := db.Model(acc).Updates(map[string]interface{}{"name": "bob", "token": "t2"}).Error; err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	records = history(t, a, acc.ID)
+	if len(records) != 2 || records[0].Action != ActionUpdate {
+		t.Fatalf("records after update = %+v", records)
+	}
+	updated := records[0].Changes
+	if len(updated) != 1 || updated["name"].Old != "alice" || updated["name"].New != "bob" {
+		t.Fatalf("update changes = %+v, want only name alice -> bob", updated)
+	}
+
+	if err := db.Model(acc).Update("password", "changed").Error; err != nil {
+		t.Fatalf("Update password: %v", err)
+	}
+	redacted := history(t, a, acc.ID)[0].Changes["password"]
+	if redacted.Old != config.RedactedValue || redacted.New != config.RedactedValue {
+		t.Fatalf("password change = %+v, want both redacted", redacted)
+	}
+
+	if err := db.Delete(&account{}, acc.ID).Error; err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	records = history(t, a, acc.ID)
+	if records[0].Action != ActionDelete {
+		t.Fatalf("latest action = %s, want delete", records[0].Action)
+	}
+	deleted := records[0].Changes
+	if deleted["name"].Old != "bob" || deleted["name"].New != nil {
+		t.Fatalf("delete name change = %+v", deleted["name"])
+	}
+}
+
+func TestAuditUnchangedUpdateWritesNothing(t *testing.T) {
+	a, db := newAuditor(t, 100)
+	acc := &account{Name: "alice"}
+	if err := db.Create(acc).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	if err := db.Model(acc).Update("name", "alice").Error; err != nil {
+		t.Fatalf("Update: %v", err)
+	}
+	if records := history(t, a, acc.ID); len(records) != 1 {
+		t.Fatalf("records = %+v, want only the create", records)
+	}
+}
+
+func TestAuditSkipsUntrackedModels(t *testing.T) {
+	_, db := newAuditor(t, 100)
+	if err := db.Create(&note{Body: "hi"}).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	var count int64
+	if err := db.Table("audit_logs").Count(&count).Error; err != nil || count != 0 {
+		t.Fatalf("audit records = %d, %v; want none", count, err)
+	}
+}
+
+func TestAuditActorAndRequestID(t *testing.T) {
+	a, db := newAuditor(t, 100)
+
+	ctx := logger.ContextWithRequestID(ContextWithActor(context.Background(), "7"), "req-1")
+	acc := &account{Name: "alice"}
+	if err := db.WithContext(ctx).Create(acc).Error; err != nil {
+		t.Fatalf("Create: %v", err)
+	}
+	records := history(t, a, acc.ID)
+	if len(records) != 1 || records[0].Actor != "7" || records[0].RequestID != "req-1" {
+		t.Fatalf("records = %+v, want actor 7, request_id req-1", records)
+	}
+	if byActor, err := a.ByActor(context.Background(), "7", HistoryQuery{}); err != nil || len(byActor) != 1 {
+		t.Fatalf("ByActor = %v, %v", byActor, err)
+	}
+}
+
+func TestAuditMaxRows(t *testing.T) {
+	_, db := newAuditor(t, 2)
+	for _, name := range []string{"a", "b", "c"} {
+		if err := db.Create(&account{Name: name}).Error; err != nil {
+			t.Fatalf("Create: %v", err)
+		}
+	}
+
+	err := db.Model(&account{}).Where("name IN ?", []string{"a", "b", "c"}).Update("name", "x").Error
+	if !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("bulk update over MAX_ROWS = %v, want ErrTooManyRows", err)
+	}
+	var changed int64
+	db.Model(&account{}).Where("name = ?", "x").Count(&changed)
+	if changed != 0 {
+		t.Fatalf("%d rows updated without audit records", changed)
+	}
+
+	// 不超过 MAX_ROWS 时每一行都有记录
+	if err := db.Model(&account{}).Where("name IN ?", []string{"a", "b"}).Update("name", "y").Error; err != nil {
+		t.Fatalf("bulk update within MAX_ROWS: %v", err)
+	}
+	var updates int64
+	db.Table("audit_logs").Where("action = ?", ActionUpdate).Count(&updates)
+	if updates != 2 {
+		t.Fatalf("update records = %d, want 2", updates)
+	}
+
+	if err := db.Where("1 = 1").Delete(&account{}).Error; !errors.Is(err, ErrTooManyRows) {
+		t.Fatalf("bulk delete over MAX_ROWS = %v, want ErrTooManyRows", err)
+	}
+}
@@ -0,0 +1,43 @@
+package audit_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/internal/model"
+	"github.com/mjcode-max/TurboGin/internal/testkit"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+)
+
+// 请求上下文经控制器、服务、DAO 传到 GORM，审计记录带有 JWT 中的操作者和请求ID
+func TestAuditThroughRequest(t *testing.T) {
+	kit := testkit.New(t, testkit.WithConfig(func(cfg *config.Config) {
+		cfg.Database.Audit.Enabled = true
+	}))
+	user := testkit.Users(kit).Create(t, func(u *model.User) { u.Name = "alice" })
+
+	kit.PATCH(t, fmt.Sprintf("/v1/users/%d", user.ID), map[string]string{"name": "bob"},
+		testkit.WithToken(kit.Token(t, user.ID)),
+		testkit.WithHeader(middleware.RequestIDHeader, "req-audit-1")).
+		AssertStatus(t, http.StatusOK)
+
+	records, err := kit.Auditor.HistoryOf(context.Background(), &model.User{}, user.ID,
+		audit.HistoryQuery{Action: audit.ActionUpdate})
+	if err != nil {
+		t.Fatalf("HistoryOf: %v", err)
+	}
+	if len(records) != 1 {
+		t.Fatalf("update records = %+v, want 1", records)
+	}
+	r := records[0]
+	if r.Actor != fmt.Sprint(user.ID) || r.RequestID != "req-audit-1" {
+		t.Fatalf("actor = %q, request_id = %q; want %d, req-audit-1", r.Actor, r.RequestID, user.ID)
+	}
+	if c := r.Changes["name"]; c.Old != "alice" || c.New != "bob" {
+		t.Fatalf("name change = %+v", c)
+	}
+}
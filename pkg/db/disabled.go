@@ -0,0 +1,62 @@
+package db
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// ErrDisabled 未启用 DATABASE 时，通过占位连接执行的操作均返回此错误
+var ErrDisabled = errors.New("db: database is not enabled (DATABASE.ENABLED=false)")
+
+var disabledOnce = sync.OnceValue(func() *gorm.DB {
+	// 不建立任何连接；Error 会复制到派生的会话中，GORM 的回调在 Error 非空时不执行SQL
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: disabledPool{}, SkipInitializeWithVersion: true}), &gorm.Config{
+		Logger:               gormlogger.Discard,
+		DisableAutomaticPing: true,
+	})
+	if err != nil {
+		panic(err)
+	}
+	db.Error = ErrDisabled
+	return db
+})
+
+// Disabled 未启用数据库时的占位连接，DAO 等依赖 *gorm.DB 的组件用它代替nil，
+// 调用时返回 ErrDisabled 而不是空指针panic
+func Disabled() *gorm.DB {
+	return disabledOnce()
+}
+
+// OrDisabled db 为nil（未启用数据库）时返回占位连接
+func OrDisabled(db *gorm.DB) *gorm.DB {
+	if db == nil {
+		return Disabled()
+	}
+	return db
+}
+
+// disabledPool 占位连接池，任何语句都返回 ErrDisabled
+type disabledPool struct{}
+
+func (disabledPool) PrepareContext(context.Context, string) (*sql.Stmt, error) {
+	return nil, ErrDisabled
+}
+
+func (disabledPool) ExecContext(context.Context, string, ...interface{}) (sql.Result, error) {
+	return nil, ErrDisabled
+}
+
+func (disabledPool) QueryContext(context.Context, string, ...interface{}) (*sql.Rows, error) {
+	return nil, ErrDisabled
+}
+
+// QueryRowContext 无法构造带错误的 *sql.Row；Error 非空时GORM不会调用到这里
+func (disabledPool) QueryRowContext(context.Context, string, ...interface{}) *sql.Row {
+	return nil
+}
@@ -1,26 +1,44 @@
 package db
 
 import (
+	"context"
+	"database/sql"
 	"fmt"
 	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
-	"gorm.io/gorm/logger"
-	"time"
+	"gorm.io/plugin/dbresolver"
+	"sync"
 )
 
-func NewGormDB(cfg *config.Config) (*gorm.DB, error) {
+func NewGormDB(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger) (*gorm.DB, error) {
 	if !cfg.Database.Enabled {
 		return nil, nil
 	}
+	return open("database", cfg.Database.Primary(), cfg, lc, log)
+}
+
+// UsePrimary 强制查询走主库，用于写后立即读等不能容忍复制延迟的场景
+func UsePrimary(db *gorm.DB) *gorm.DB {
+	return db.Clauses(dbresolver.Write)
+}
+
+// open 建立连接（配置了副本时注册读写分离），并在应用关闭时释放
+func open(name string, src config.DataSourceConfig, cfg *config.Config, lc *app.Lifecycle, log *logger.Logger) (*gorm.DB, error) {
+	dialector, err := dialect(src.Driver, src.DSN)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
 
 	// 初始化GORM
-	db, err := gorm.Open(mysql.Open(cfg.Database.DSN), &gorm.Config{
-		Logger:      logger.Default.LogMode(logger.Silent), // 生产环境可改为Warn
-		PrepareStmt: true,                                  // 开启预编译
+	db, err := gorm.Open(dialector, &gorm.Config{
+		Logger:      newGormLogger(&cfg.Database, log.WithFields(logger.String("component", "gorm"), logger.String("db", name))),
+		PrepareStmt: true, // 开启预编译
 	})
 	if err != nil {
-		return nil, fmt.Errorf("failed to connect database: %w", err)
+		return nil, fmt.Errorf("failed to connect %s: %w", name, err)
 	}
 
 	sqlDB, err := db.DB()
@@ -28,19 +46,109 @@ func NewGormDB(cfg *config.Config) (*gorm.DB, error) {
 		return nil, fmt.Errorf("failed to get sql.DB: %w", err)
 	}
 
-	// 连接池配置
-	sqlDB.SetMaxIdleConns(cfg.Database.MaxIdleConns)
-	sqlDB.SetMaxOpenConns(cfg.Database.MaxOpenConns)
-	sqlDB.SetConnMaxLifetime(30 * time.Minute)
+	// 读写分离：查询路由到副本，写操作、事务和 UsePrimary 走主库
+	var resolver *dbresolver.DBResolver
+	if len(src.Replicas) > 0 {
+		replicas := make([]gorm.Dialector, 0, len(src.Replicas))
+		for _, dsn := range src.Replicas {
+			d, err := dialect(src.Driver, dsn)
+			if err != nil {
+				_ = sqlDB.Close()
+				return nil, fmt.Errorf("%s replica: %w", name, err)
+			}
+			replicas = append(replicas, d)
+		}
+		resolver = dbresolver.Register(dbresolver.Config{Replicas: replicas, Policy: dbresolver.RandomPolicy{}})
+		if err := db.Use(resolver); err != nil {
+			_ = sqlDB.Close()
+			return nil, fmt.Errorf("failed to register %s replicas: %w", name, err)
+		}
+	}
+
+	// 连接池配置，副本与主库相同
+	sqlDB.SetMaxIdleConns(src.MaxIdleConns)
+	sqlDB.SetMaxOpenConns(src.MaxOpenConns)
+	sqlDB.SetConnMaxLifetime(src.ConnMaxLifetime)
+	sqlDB.SetConnMaxIdleTime(src.ConnMaxIdleTime)
+	if resolver != nil {
+		resolver.SetMaxIdleConns(src.MaxIdleConns).
+			SetMaxOpenConns(src.MaxOpenConns).
+			SetConnMaxLifetime(src.ConnMaxLifetime).
+			SetConnMaxIdleTime(src.ConnMaxIdleTime)
+	}
+
+	closeAll := func() error {
+		err := sqlDB.Close()
+		if resolver != nil {
+			_ = eachReplica(resolver, sqlDB, (*sql.DB).Close)
+		}
+		return err
+	}
 
 	// 健康检查
 	if err := sqlDB.Ping(); err != nil {
-		return nil, fmt.Errorf("database ping failed: %w", err)
+		_ = closeAll()
+		return nil, fmt.Errorf("%s ping failed: %w", name, err)
+	}
+	if resolver != nil {
+		if err := eachReplica(resolver, sqlDB, (*sql.DB).Ping); err != nil {
+			_ = closeAll()
+			return nil, fmt.Errorf("%s replica ping failed: %w", name, err)
+		}
 	}
 
+	// 连接已建立，应用关闭时释放
+	lc.Append(app.Hook{
+		Name: name,
+		OnStop: func(context.Context) error {
+			return closeAll()
+		},
+	})
+
 	return db, nil
 }
 
+// DialectorFunc 根据DSN创建GORM方言
+type DialectorFunc func(dsn string) gorm.Dialector
+
+var (
+	driversMu sync.RWMutex
+	drivers   = map[string]DialectorFunc{"mysql": mysql.Open}
+)
+
+// RegisterDriver 注册数据库驱动，DATABASE.DRIVER 按名称选择；
+// 内置 mysql，其他驱动按需引入以免增大二进制体积（例如 testkit 注册 sqlite）
+func RegisterDriver(name string, fn DialectorFunc) {
+	driversMu.Lock()
+	defer driversMu.Unlock()
+	drivers[name] = fn
+	config.RegisterDriver(name)
+}
+
+// dialect 按驱动名创建方言
+func dialect(driver, dsn string) (gorm.Dialector, error) {
+	if driver == "" {
+		driver = "mysql"
+	}
+	driversMu.RLock()
+	fn, ok := drivers[driver]
+	driversMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported database driver %q", driver)
+	}
+	return fn(dsn), nil
+}
+
+// eachReplica 对副本连接池执行fn，跳过主库
+func eachReplica(resolver *dbresolver.DBResolver, primary *sql.DB, fn func(*sql.DB) error) error {
+	return resolver.Call(func(pool gorm.ConnPool) error {
+		if replica, ok := pool.(*sql.DB); ok && replica != primary {
+			return fn(replica)
+		}
+		return nil
+	})
+}
+
 // HealthCheck 健康检查API
 func HealthCheck(db *gorm.DB) error {
 	sqlDB, err := db.DB()
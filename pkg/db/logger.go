@@ -0,0 +1,150 @@
+package db
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// gormLogger 将GORM日志输出到zap，级别由 DATABASE.LOG_LEVEL 控制：
+// error 记录执行失败的SQL，warn 额外记录慢查询，info 记录所有SQL
+type gormLogger struct {
+	log       *logger.Logger
+	level     gormlogger.LogLevel
+	slow      time.Duration
+	logParams bool
+}
+
+func newGormLogger(cfg *config.DatabaseConfig, log *logger.Logger) gormlogger.Interface {
+	return &gormLogger{
+		log:       log,
+		level:     parseLogLevel(cfg.LogLevel),
+		slow:      cfg.SlowThreshold,
+		logParams: cfg.LogParams,
+	}
+}
+
+func parseLogLevel(level string) gormlogger.LogLevel {
+	switch level {
+	case "silent":
+		return gormlogger.Silent
+	case "error":
+		return gormlogger.Error
+	case "info":
+		return gormlogger.Info
+	default:
+		return gormlogger.Warn
+	}
+}
+
+func (l *gormLogger) LogMode(level gormlogger.LogLevel) gormlogger.Interface {
+	cp := *l
+	cp.level = level
+	return &cp
+}
+
+func (l *gormLogger) Info(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Info {
+		l.log.Info(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Warn(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Warn {
+		l.log.Warn(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Error(ctx context.Context, msg string, args ...interface{}) {
+	if l.level >= gormlogger.Error {
+		l.log.Error(fmt.Sprintf(msg, args...), contextFields(ctx)...)
+	}
+}
+
+func (l *gormLogger) Trace(ctx context.Context, begin time.Time, fc func() (string, int64), err error) {
+	if l.level <= gormlogger.Silent {
+		return
+	}
+
+	elapsed := time.Since(begin)
+	failed := err != nil && !errors.Is(err, gorm.ErrRecordNotFound)
+	slow := l.slow > 0 && elapsed > l.slow
+	switch {
+	case failed && l.level >= gormlogger.Error:
+		l.log.Error("SQL failed", l.fields(ctx, elapsed, fc, logger.Error(err))...)
+	case slow && l.level >= gormlogger.Warn:
+		l.log.Warn("Slow SQL", l.fields(ctx, elapsed, fc, logger.Duration("threshold", l.slow))...)
+	case l.level >= gormlogger.Info:
+		l.log.Info("SQL", l.fields(ctx, elapsed, fc)...)
+	}
+}
+
+// ParamsFilter 未开启 LOG_PARAMS 时日志中的SQL保留占位符，避免输出密码、手机号等参数
+func (l *gormLogger) ParamsFilter(_ context.Context, sql string, params ...interface{}) (string, []interface{}) {
+	if l.logParams {
+		return sql, params
+	}
+	return sql, nil
+}
+
+func (l *gormLogger) fields(ctx context.Context, elapsed time.Duration, fc func() (string, int64), extra ...logger.Field) []logger.Field {
+	sql, rows := fc()
+	fields := append(contextFields(ctx),
+		logger.String("sql", sql),
+		logger.Duration("elapsed", elapsed),
+		logger.String("source", source()),
+	)
+	if rows >= 0 {
+		fields = append(fields, logger.Int64("rows", rows))
+	}
+	return append(fields, extra...)
+}
+
+// source 发起查询的业务代码位置，跳过GORM、本包以及通用DAO的调用栈
+func source() string {
+	pcs := make([]uintptr, 32)
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(3, pcs)])
+	for {
+		frame, more := frames.Next()
+		if !skipFrame(frame.File) {
+			return frame.File + ":" + strconv.Itoa(frame.Line)
+		}
+		if !more {
+			return ""
+		}
+	}
+}
+
+func skipFrame(file string) bool {
+	return strings.Contains(file, "gorm.io/") ||
+		strings.Contains(file, "/pkg/db/") ||
+		strings.HasSuffix(file, "/internal/dao/base_dao.go")
+}
+
+// contextFields 从上下文关联请求ID与链路追踪ID
+func contextFields(ctx context.Context) []logger.Field {
+	if ctx == nil {
+		return nil
+	}
+	var fields []logger.Field
+	if id := logger.RequestIDFromContext(ctx); id != "" {
+		fields = append(fields, logger.String("request_id", id))
+	}
+	if sc := trace.SpanContextFromContext(ctx); sc.IsValid() {
+		fields = append(fields,
+			logger.String("trace_id", sc.TraceID().String()),
+			logger.String("span_id", sc.SpanID().String()),
+		)
+	}
+	return fields
+}
@@ -0,0 +1,20 @@
+package db
+
+import (
+	"context"
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+func TestContextFieldsRequestID(t *testing.T) {
+	if fields := contextFields(context.Background()); len(fields) != 0 {
+		t.Fatalf("contextFields(empty) = %v, want none", fields)
+	}
+
+	ctx := logger.ContextWithRequestID(context.Background(), "req-1")
+	fields := contextFields(ctx)
+	if len(fields) != 1 || fields[0].Key != "request_id" || fields[0].String != "req-1" {
+		t.Fatalf("contextFields = %v, want request_id=req-1", fields)
+	}
+}
@@ -0,0 +1,75 @@
+package db
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"gorm.io/gorm"
+)
+
+// Sources DATABASE.SOURCES 中配置的具名数据源
+type Sources struct {
+	dbs map[string]*gorm.DB
+}
+
+// NewSources 构造函数，连接所有具名数据源；未启用数据库时返回nil
+func NewSources(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger) (*Sources, error) {
+	if !cfg.Database.Enabled {
+		return nil, nil
+	}
+
+	s := &Sources{dbs: make(map[string]*gorm.DB, len(cfg.Database.Sources))}
+	for name, src := range cfg.Database.Sources {
+		// 未配置的参数沿用主库
+		if src.Driver == "" {
+			src.Driver = cfg.Database.Driver
+		}
+		if src.MaxIdleConns == 0 {
+			src.MaxIdleConns = cfg.Database.MaxIdleConns
+		}
+		if src.MaxOpenConns == 0 {
+			src.MaxOpenConns = cfg.Database.MaxOpenConns
+		}
+		if src.ConnMaxLifetime == 0 {
+			src.ConnMaxLifetime = cfg.Database.ConnMaxLifetime
+		}
+		if src.ConnMaxIdleTime == 0 {
+			src.ConnMaxIdleTime = cfg.Database.ConnMaxIdleTime
+		}
+
+		db, err := open("database:"+name, src, cfg, lc, log)
+		if err != nil {
+			return nil, err
+		}
+		s.dbs[name] = db
+	}
+	return s, nil
+}
+
+// Get 按名称获取数据源
+func (s *Sources) Get(name string) (*gorm.DB, error) {
+	if s == nil {
+		return nil, fmt.Errorf("db: database disabled, source %q unavailable", name)
+	}
+	db, ok := s.dbs[name]
+	if !ok {
+		return nil, fmt.Errorf("db: unknown source %q", name)
+	}
+	return db, nil
+}
+
+// Names 已配置的数据源名称
+func (s *Sources) Names() []string {
+	if s == nil {
+		return nil
+	}
+	names := make([]string, 0, len(s.dbs))
+	for name := range s.dbs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,71 @@
+package db
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"gorm.io/driver/sqlite"
+)
+
+func init() {
+	RegisterDriver("sqlite", sqlite.Open)
+}
+
+func TestSources(t *testing.T) {
+	dir := t.TempDir()
+	cfg := &config.Config{
+		Server: config.ServerConfig{ShutdownTimeout: time.Second},
+		Database: config.DatabaseConfig{
+			Enabled:      true,
+			Driver:       "sqlite",
+			LogLevel:     "silent",
+			MaxOpenConns: 3,
+			Sources: map[string]config.DataSourceConfig{
+				"analytics": {DSN: filepath.Join(dir, "analytics.db")},
+			},
+		},
+	}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+
+	sources, err := NewSources(cfg, lc, logger.NewNop())
+	if err != nil {
+		t.Fatalf("NewSources: %v", err)
+	}
+	defer lc.Stop(context.Background())
+
+	if names := sources.Names(); len(names) != 1 || names[0] != "analytics" {
+		t.Fatalf("Names() = %v", names)
+	}
+	analytics, err := sources.Get("analytics")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	sqlDB, err := analytics.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 未配置的连接池参数沿用主库
+	if got := sqlDB.Stats().MaxOpenConnections; got != 3 {
+		t.Fatalf("MaxOpenConnections = %d, want 3", got)
+	}
+	if _, err := sources.Get("missing"); err == nil {
+		t.Fatal("Get of unknown source succeeded")
+	}
+}
+
+func TestSourcesDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	sources, err := NewSources(cfg, lc, logger.NewNop())
+	if err != nil || sources != nil {
+		t.Fatalf("NewSources() = %v, %v; want nil, nil", sources, err)
+	}
+	if _, err := sources.Get("analytics"); err == nil {
+		t.Fatal("Get on disabled sources succeeded")
+	}
+}
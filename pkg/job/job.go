@@ -0,0 +1,152 @@
+package job
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultQueue 默认队列名称
+const DefaultQueue = "default"
+
+var (
+	// ErrDisabled 任务队列未启用
+	ErrDisabled = errors.New("job: queue disabled")
+	// ErrSkipRetry 处理函数返回包装了该错误的err时不再重试，直接进入死信队列
+	ErrSkipRetry = errors.New("job: skip retry")
+	// ErrLeaseLost 任务处理超过可见期后已被重新投递，本次的确认、重试或移入死信被丢弃
+	ErrLeaseLost = errors.New("job: lease lost")
+)
+
+// Job 队列中的任务
+type Job struct {
+	ID         string          `json:"id"`
+	Type       string          `json:"type"`
+	Queue      string          `json:"queue"`
+	Payload    json.RawMessage `json:"payload"`
+	Attempts   int             `json:"attempts"`
+	MaxRetries int             `json:"max_retries"`
+	RunAt      time.Time       `json:"run_at"`
+	CreatedAt  time.Time       `json:"created_at"`
+	LastError  string          `json:"last_error,omitempty"`
+
+	// raw 出队时的租约标识（Redis存储为租约令牌加原始编码），确认/重试时据此校验任务仍由本次投递持有
+	raw string
+}
+
+// Decode 解析任务负载
+func (j *Job) Decode(v interface{}) error {
+	return json.Unmarshal(j.Payload, v)
+}
+
+// Handler 任务处理函数
+type Handler func(ctx context.Context, job *Job) error
+
+// Backend 队列存储
+type Backend interface {
+	// Enqueue 入队，RunAt晚于当前时间时进入延迟队列
+	Enqueue(ctx context.Context, job *Job) error
+	// Dequeue 取出一个就绪任务，队列为空时返回(nil, nil)；任务在visibility内未确认将重新入队
+	Dequeue(ctx context.Context, queue string, visibility time.Duration) (*Job, error)
+	// Ack 确认任务处理完成；Ack、Retry、Kill 在任务已因超时重新入队时返回 ErrLeaseLost
+	Ack(ctx context.Context, job *Job) error
+	// Retry 将任务放回延迟队列，在runAt重新执行
+	Retry(ctx context.Context, job *Job, runAt time.Time) error
+	// Kill 将任务移入死信队列
+	Kill(ctx context.Context, job *Job) error
+	// Promote 将到期的延迟任务和处理超时的任务移回就绪队列
+	Promote(ctx context.Context, queue string, now time.Time) error
+	// Dead 查看死信队列中的任务
+	Dead(ctx context.Context, queue string, limit int64) ([]*Job, error)
+}
+
+type enqueueOptions struct {
+	queue      string
+	runAt      time.Time
+	maxRetries int
+}
+
+// EnqueueOption 入队选项
+type EnqueueOption func(*enqueueOptions)
+
+// Queue 指定队列
+func Queue(name string) EnqueueOption {
+	return func(o *enqueueOptions) { o.queue = name }
+}
+
+// Delay 延迟执行
+func Delay(d time.Duration) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = time.Now().Add(d) }
+}
+
+// ProcessAt 在指定时间执行
+func ProcessAt(t time.Time) EnqueueOption {
+	return func(o *enqueueOptions) { o.runAt = t }
+}
+
+// MaxRetries 覆盖默认最大重试次数
+func MaxRetries(n int) EnqueueOption {
+	return func(o *enqueueOptions) { o.maxRetries = n }
+}
+
+// newJob 构建任务
+func newJob(jobType string, payload interface{}, o *enqueueOptions) (*Job, error) {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("job: encode payload for %q: %w", jobType, err)
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("job: generate id: %w", err)
+	}
+
+	now := time.Now()
+	runAt := o.runAt
+	if runAt.IsZero() {
+		runAt = now
+	}
+
+	return &Job{
+		ID:         hex.EncodeToString(id),
+		Type:       jobType,
+		Queue:      o.queue,
+		Payload:    data,
+		MaxRetries: o.maxRetries,
+		RunAt:      runAt,
+		CreatedAt:  now,
+	}, nil
+}
+
+// encode 序列化任务
+func encode(job *Job) (string, error) {
+	data, err := json.Marshal(job)
+	if err != nil {
+		return "", fmt.Errorf("job: encode %s: %w", job.ID, err)
+	}
+	return string(data), nil
+}
+
+// leaseLen 租约令牌长度（十六进制字符数）
+const leaseLen = 16
+
+// newLease 每次出队生成的租约令牌
+func newLease() string {
+	b := make([]byte, leaseLen/2)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// decode 反序列化任务
+func decode(raw string) (*Job, error) {
+	var job Job
+	if err := json.Unmarshal([]byte(raw), &job); err != nil {
+		return nil, fmt.Errorf("job: decode: %w", err)
+	}
+	job.raw = raw
+	return &job, nil
+}
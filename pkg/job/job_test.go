@@ -0,0 +1,356 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// backends 分别基于内存和miniredis的存储
+func backends(t *testing.T) map[string]Backend {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cli := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = cli.Close() })
+
+	return map[string]Backend{
+		"memory": NewMemoryBackend(),
+		"redis":  &redisBackend{cli: cli},
+	}
+}
+
+func testConfig() *config.JobConfig {
+	return &config.JobConfig{
+		Enabled:           true,
+		Queues:            []string{DefaultQueue},
+		Concurrency:       2,
+		MaxRetries:        2,
+		RetryMinBackoff:   time.Millisecond,
+		RetryMaxBackoff:   5 * time.Millisecond,
+		PollInterval:      5 * time.Millisecond,
+		VisibilityTimeout: time.Second,
+	}
+}
+
+// startManager 启动Manager，测试结束时停止
+func startManager(t *testing.T, backend Backend, register func(m *Manager)) *Manager {
+	t.Helper()
+	m := NewManagerWithBackend(testConfig(), backend, logger.NewNop())
+	register(m)
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	t.Cleanup(func() {
+		ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+		defer cancel()
+		_ = m.Stop(ctx)
+	})
+	return m
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition not met before timeout")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestDisabledManager(t *testing.T) {
+	var m *Manager
+	if _, err := m.Enqueue(context.Background(), "email", nil); !errors.Is(err, ErrDisabled) {
+		t.Fatalf("Enqueue on nil manager = %v, want ErrDisabled", err)
+	}
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start on nil manager = %v", err)
+	}
+}
+
+func TestBackendLifecycle(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			if job, err := b.Dequeue(ctx, DefaultQueue, time.Second); job != nil || err != nil {
+				t.Fatalf("Dequeue on empty queue = %v, %v", job, err)
+			}
+
+			job, err := newJob("email", map[string]string{"to": "a@example.com"}, &enqueueOptions{queue: DefaultQueue})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Enqueue(ctx, job); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			got, err := b.Dequeue(ctx, DefaultQueue, time.Second)
+			if err != nil || got == nil || got.ID != job.ID {
+				t.Fatalf("Dequeue = %v, %v; want job %s", got, err, job.ID)
+			}
+			if err := b.Kill(ctx, got); err != nil {
+				t.Fatalf("Kill: %v", err)
+			}
+			dead, err := b.Dead(ctx, DefaultQueue, 10)
+			if err != nil || len(dead) != 1 || dead[0].ID != job.ID {
+				t.Fatalf("Dead = %v, %v; want job %s", dead, err, job.ID)
+			}
+		})
+	}
+}
+
+func TestBackendDelayedAndVisibility(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			now := time.Now()
+			job, err := newJob("report", nil, &enqueueOptions{queue: DefaultQueue, runAt: now.Add(time.Hour)})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Enqueue(ctx, job); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			if got, _ := b.Dequeue(ctx, DefaultQueue, time.Second); got != nil {
+				t.Fatal("delayed job dequeued before run time")
+			}
+
+			if err := b.Promote(ctx, DefaultQueue, now.Add(2*time.Hour)); err != nil {
+				t.Fatalf("Promote: %v", err)
+			}
+			got, err := b.Dequeue(ctx, DefaultQueue, time.Minute)
+			if err != nil || got == nil {
+				t.Fatalf("Dequeue after promote = %v, %v", got, err)
+			}
+
+			// 超过处理期限未确认的任务重新入队
+			if err := b.Promote(ctx, DefaultQueue, now.Add(3*time.Hour)); err != nil {
+				t.Fatalf("Promote: %v", err)
+			}
+			again, err := b.Dequeue(ctx, DefaultQueue, time.Minute)
+			if err != nil || again == nil || again.ID != job.ID {
+				t.Fatalf("unacked job not redelivered: %v, %v", again, err)
+			}
+			if err := b.Ack(ctx, again); err != nil {
+				t.Fatalf("Ack: %v", err)
+			}
+		})
+	}
+}
+
+func TestManagerProcessesTypedJob(t *testing.T) {
+	type email struct {
+		To string `json:"to"`
+	}
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			got := make(chan string, 1)
+			m := startManager(t, b, func(m *Manager) {
+				Register(m, "email", func(_ context.Context, p email) error {
+					got <- p.To
+					return nil
+				})
+			})
+			if _, err := m.Enqueue(context.Background(), "email", email{To: "a@example.com"}); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			select {
+			case to := <-got:
+				if to != "a@example.com" {
+					t.Fatalf("payload = %q", to)
+				}
+			case <-time.After(2 * time.Second):
+				t.Fatal("job not processed")
+			}
+		})
+	}
+}
+
+func TestManagerRetriesThenSucceeds(t *testing.T) {
+	var attempts atomic.Int64
+	m := startManager(t, NewMemoryBackend(), func(m *Manager) {
+		m.Handle("flaky", func(context.Context, *Job) error {
+			if attempts.Add(1) < 3 {
+				return fmt.Errorf("temporary")
+			}
+			return nil
+		})
+	})
+	if _, err := m.Enqueue(context.Background(), "flaky", nil); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	waitFor(t, func() bool { return attempts.Load() == 3 })
+
+	dead, err := m.DeadJobs(context.Background(), DefaultQueue, 10)
+	if err != nil || len(dead) != 0 {
+		t.Fatalf("DeadJobs = %v, %v; want none", dead, err)
+	}
+}
+
+func TestManagerDeadLetter(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler Handler
+		want    int // 进入死信队列前的执行次数
+	}{
+		{name: "max retries", handler: func(context.Context, *Job) error { return errors.New("always") }, want: 3},
+		{name: "skip retry", handler: func(context.Context, *Job) error { return fmt.Errorf("bad input: %w", ErrSkipRetry) }, want: 1},
+		{name: "panic", handler: func(context.Context, *Job) error { panic("boom") }, want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var attempts atomic.Int64
+			m := startManager(t, NewMemoryBackend(), func(m *Manager) {
+				m.Handle("task", func(ctx context.Context, job *Job) error {
+					attempts.Add(1)
+					return tt.handler(ctx, job)
+				})
+			})
+			if _, err := m.Enqueue(context.Background(), "task", nil); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+
+			var dead []*Job
+			waitFor(t, func() bool {
+				dead, _ = m.DeadJobs(context.Background(), DefaultQueue, 10)
+				return len(dead) == 1
+			})
+			if got := attempts.Load(); got != int64(tt.want) {
+				t.Fatalf("attempts = %d, want %d", got, tt.want)
+			}
+			if dead[0].LastError == "" {
+				t.Fatal("dead job has no LastError")
+			}
+		})
+	}
+}
+
+func TestManagerUnknownType(t *testing.T) {
+	m := startManager(t, NewMemoryBackend(), func(*Manager) {})
+	if _, err := m.Enqueue(context.Background(), "unknown", nil); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	waitFor(t, func() bool {
+		dead, _ := m.DeadJobs(context.Background(), DefaultQueue, 10)
+		return len(dead) == 1
+	})
+}
+
+// 任务超时重新投递后，原处理者的确认、重试、移入死信都不生效，避免重复任务
+func TestBackendStaleLease(t *testing.T) {
+	ctx := context.Background()
+	for name, b := range backends(t) {
+		t.Run(name, func(t *testing.T) {
+			job, err := newJob("report", nil, &enqueueOptions{queue: DefaultQueue})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := b.Enqueue(ctx, job); err != nil {
+				t.Fatalf("Enqueue: %v", err)
+			}
+			first, err := b.Dequeue(ctx, DefaultQueue, time.Millisecond)
+			if err != nil || first == nil {
+				t.Fatalf("Dequeue = %v, %v", first, err)
+			}
+			if err := b.Promote(ctx, DefaultQueue, time.Now().Add(time.Second)); err != nil {
+				t.Fatalf("Promote: %v", err)
+			}
+			second, err := b.Dequeue(ctx, DefaultQueue, time.Minute)
+			if err != nil || second == nil || second.ID != job.ID {
+				t.Fatalf("redelivery = %v, %v", second, err)
+			}
+
+			if err := b.Retry(ctx, first, time.Now()); !errors.Is(err, ErrLeaseLost) {
+				t.Fatalf("Retry with stale lease = %v, want ErrLeaseLost", err)
+			}
+			if err := b.Kill(ctx, first); !errors.Is(err, ErrLeaseLost) {
+				t.Fatalf("Kill with stale lease = %v, want ErrLeaseLost", err)
+			}
+			if err := b.Ack(ctx, first); !errors.Is(err, ErrLeaseLost) {
+				t.Fatalf("Ack with stale lease = %v, want ErrLeaseLost", err)
+			}
+			if err := b.Promote(ctx, DefaultQueue, time.Now()); err != nil {
+				t.Fatalf("Promote: %v", err)
+			}
+			if again, _ := b.Dequeue(ctx, DefaultQueue, time.Minute); again != nil {
+				t.Fatalf("stale retry duplicated job %s", again.ID)
+			}
+			if dead, _ := b.Dead(ctx, DefaultQueue, 10); len(dead) != 0 {
+				t.Fatalf("stale kill moved job to dead letter queue: %v", dead)
+			}
+
+			// 当前投递仍可确认，确认后不再重新入队
+			if err := b.Ack(ctx, second); err != nil {
+				t.Fatalf("Ack: %v", err)
+			}
+			if err := b.Promote(ctx, DefaultQueue, time.Now().Add(time.Hour)); err != nil {
+				t.Fatalf("Promote: %v", err)
+			}
+			if again, _ := b.Dequeue(ctx, DefaultQueue, time.Minute); again != nil {
+				t.Fatalf("acked job redelivered: %s", again.ID)
+			}
+		})
+	}
+}
+
+func TestManagerHandlerDeadlineBeforeVisibility(t *testing.T) {
+	deadline := make(chan time.Duration, 1)
+	start := time.Now()
+	m := startManager(t, NewMemoryBackend(), func(m *Manager) {
+		m.Handle("task", func(ctx context.Context, _ *Job) error {
+			d, _ := ctx.Deadline()
+			deadline <- d.Sub(start)
+			return nil
+		})
+	})
+	if _, err := m.Enqueue(context.Background(), "task", nil); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	select {
+	case d := <-deadline:
+		if d >= testConfig().VisibilityTimeout {
+			t.Fatalf("handler deadline %s not shorter than visibility timeout", d)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("job not processed")
+	}
+}
+
+func TestManagerStopIsBounded(t *testing.T) {
+	started := make(chan struct{})
+	release := make(chan struct{})
+	defer close(release)
+
+	m := NewManagerWithBackend(testConfig(), NewMemoryBackend(), logger.NewNop())
+	m.Handle("stuck", func(context.Context, *Job) error {
+		close(started)
+		<-release // 不响应取消
+		return nil
+	})
+	if err := m.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	if _, err := m.Enqueue(context.Background(), "stuck", nil); err != nil {
+		t.Fatalf("Enqueue: %v", err)
+	}
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	begin := time.Now()
+	if err := m.Stop(ctx); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Stop = %v, want deadline exceeded", err)
+	}
+	if elapsed := time.Since(begin); elapsed > time.Second {
+		t.Fatalf("Stop blocked for %s on a handler ignoring ctx", elapsed)
+	}
+}
@@ -0,0 +1,319 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+)
+
+// Manager 任务生产与消费入口
+type Manager struct {
+	backend Backend
+	cfg     *config.JobConfig
+	log     *logger.Logger
+
+	mu       sync.RWMutex
+	handlers map[string]Handler
+
+	cancel   context.CancelFunc // 停止拉取新任务
+	abort    context.CancelFunc // 停止超时后取消正在执行的任务
+	wg       sync.WaitGroup
+	started  bool
+	stopOnce sync.Once
+}
+
+// NewManager 构造函数
+func NewManager(cfg *config.Config, lc *app.Lifecycle, client *redis.Client, log *logger.Logger) (*Manager, error) {
+	if !cfg.Job.Enabled {
+		return nil, nil
+	}
+
+	var backend Backend
+	switch cfg.Job.Backend {
+	case "redis":
+		if client == nil {
+			return nil, fmt.Errorf("job: redis backend requires REDIS.ENABLED")
+		}
+		backend = NewRedisBackend(client)
+	case "memory":
+		backend = NewMemoryBackend()
+	default:
+		return nil, fmt.Errorf("job: unsupported backend %q", cfg.Job.Backend)
+	}
+
+	m := NewManagerWithBackend(&cfg.Job, backend, log)
+	lc.Append(app.Hook{
+		Name:    "job",
+		OnStart: func(context.Context) error { return m.Start() },
+		OnStop:  m.Stop,
+	})
+	return m, nil
+}
+
+// NewManagerWithBackend 使用指定存储创建Manager（测试中可传入NewMemoryBackend()）
+func NewManagerWithBackend(cfg *config.JobConfig, backend Backend, log *logger.Logger) *Manager {
+	return &Manager{
+		backend:  backend,
+		cfg:      cfg,
+		log:      log.WithFields(logger.String("component", "job")),
+		handlers: make(map[string]Handler),
+	}
+}
+
+// Handle 注册任务处理函数
+func (m *Manager) Handle(jobType string, h Handler) {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.handlers[jobType] = h
+}
+
+// Register 注册强类型任务处理函数，负载自动从JSON解析
+func Register[T any](m *Manager, jobType string, fn func(ctx context.Context, payload T) error) {
+	m.Handle(jobType, func(ctx context.Context, job *Job) error {
+		var payload T
+		if err := job.Decode(&payload); err != nil {
+			return fmt.Errorf("%w: decode payload: %v", ErrSkipRetry, err)
+		}
+		return fn(ctx, payload)
+	})
+}
+
+// Enqueue 投递任务
+func (m *Manager) Enqueue(ctx context.Context, jobType string, payload interface{}, opts ...EnqueueOption) (*Job, error) {
+	if m == nil {
+		return nil, ErrDisabled
+	}
+
+	o := &enqueueOptions{queue: DefaultQueue, maxRetries: m.cfg.MaxRetries}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	job, err := newJob(jobType, payload, o)
+	if err != nil {
+		return nil, err
+	}
+	if err := m.backend.Enqueue(ctx, job); err != nil {
+		return nil, fmt.Errorf("job: enqueue %q: %w", jobType, err)
+	}
+	return job, nil
+}
+
+// DeadJobs 查看死信队列
+func (m *Manager) DeadJobs(ctx context.Context, queue string, limit int64) ([]*Job, error) {
+	if m == nil {
+		return nil, ErrDisabled
+	}
+	return m.backend.Dead(ctx, queue, limit)
+}
+
+// Start 启动worker（非阻塞）
+func (m *Manager) Start() error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.started {
+		return fmt.Errorf("job: manager already started")
+	}
+	m.started = true
+
+	runCtx, cancel := context.WithCancel(context.Background())
+	handlerCtx, abort := context.WithCancel(context.Background())
+	m.cancel, m.abort = cancel, abort
+
+	queues := m.cfg.Queues
+	if len(queues) == 0 {
+		queues = []string{DefaultQueue}
+	}
+
+	for _, queue := range queues {
+		m.wg.Add(1)
+		go m.promoter(runCtx, queue)
+
+		for i := 0; i < m.cfg.Concurrency; i++ {
+			m.wg.Add(1)
+			go m.worker(runCtx, handlerCtx, queue)
+		}
+	}
+
+	m.log.Info("Job workers started",
+		logger.Strings("queues", queues),
+		logger.Int("concurrency", m.cfg.Concurrency))
+	return nil
+}
+
+// Stop 停止拉取新任务并等待正在执行的任务完成，ctx到期后取消未完成的任务
+func (m *Manager) Stop(ctx context.Context) error {
+	if m == nil {
+		return nil
+	}
+
+	m.mu.RLock()
+	started := m.started
+	m.mu.RUnlock()
+	if !started {
+		return nil
+	}
+
+	var err error
+	m.stopOnce.Do(func() {
+		m.cancel()
+
+		done := make(chan struct{})
+		go func() {
+			m.wg.Wait()
+			close(done)
+		}()
+
+		select {
+		case <-done:
+			m.log.Info("Job workers stopped")
+		case <-ctx.Done():
+			// 取消正在执行的任务后不再等待，不响应取消的处理函数不会阻塞关闭；
+			// 未确认的任务在可见期后重新投递
+			err = fmt.Errorf("job: stop: %w", ctx.Err())
+			m.log.Warn("Job workers did not stop in time, abandoning running jobs")
+		}
+		m.abort()
+	})
+	return err
+}
+
+// promoter 定期搬运到期的延迟任务
+func (m *Manager) promoter(ctx context.Context, queue string) {
+	defer m.wg.Done()
+
+	ticker := time.NewTicker(m.cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			if err := m.backend.Promote(ctx, queue, now); err != nil && ctx.Err() == nil {
+				m.log.Warn("Promote jobs failed", logger.String("queue", queue), logger.Error(err))
+			}
+		}
+	}
+}
+
+// worker 循环拉取并执行任务
+func (m *Manager) worker(ctx, handlerCtx context.Context, queue string) {
+	defer m.wg.Done()
+
+	for {
+		if ctx.Err() != nil {
+			return
+		}
+
+		job, err := m.backend.Dequeue(ctx, queue, m.cfg.VisibilityTimeout)
+		if err != nil && ctx.Err() == nil {
+			m.log.Warn("Dequeue job failed", logger.String("queue", queue), logger.Error(err))
+		}
+		if job == nil {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(m.cfg.PollInterval):
+			}
+			continue
+		}
+
+		m.process(handlerCtx, job)
+	}
+}
+
+// process 执行单个任务并根据结果确认、重试或移入死信队列
+func (m *Manager) process(ctx context.Context, job *Job) {
+	// 确认操作不受停止流程影响
+	bg := context.WithoutCancel(ctx)
+	fields := []logger.Field{
+		logger.String("job_id", job.ID),
+		logger.String("type", job.Type),
+		logger.String("queue", job.Queue),
+		logger.Int("attempt", job.Attempts+1),
+	}
+
+	m.mu.RLock()
+	h, ok := m.handlers[job.Type]
+	m.mu.RUnlock()
+
+	var err error
+	if !ok {
+		err = fmt.Errorf("%w: no handler registered for %q", ErrSkipRetry, job.Type)
+	} else {
+		jobCtx, cancel := context.WithTimeout(ctx, handlerTimeout(m.cfg.VisibilityTimeout))
+		err = safeRun(jobCtx, h, job)
+		cancel()
+	}
+
+	if err == nil {
+		m.settle(fields, "Ack", m.backend.Ack(bg, job))
+		return
+	}
+
+	job.Attempts++
+	job.LastError = err.Error()
+
+	if errors.Is(err, ErrSkipRetry) || job.Attempts > job.MaxRetries {
+		m.log.Error("Job moved to dead letter queue", append(fields, logger.Error(err))...)
+		m.settle(fields, "Kill", m.backend.Kill(bg, job))
+		return
+	}
+
+	runAt := time.Now().Add(m.backoff(job.Attempts))
+	m.log.Warn("Job failed, will retry", append(fields, logger.Error(err), logger.Time("retry_at", runAt))...)
+	m.settle(fields, "Retry", m.backend.Retry(bg, job, runAt))
+}
+
+// settle 记录确认、重试、移入死信的结果；租约已失效说明任务已被重新投递，本次结果丢弃
+func (m *Manager) settle(fields []logger.Field, op string, err error) {
+	switch {
+	case err == nil:
+	case errors.Is(err, ErrLeaseLost):
+		m.log.Warn("Job exceeded visibility timeout and was redelivered, result discarded",
+			append(fields, logger.String("op", op))...)
+	default:
+		m.log.Error(op+" job failed", append(fields, logger.Error(err))...)
+	}
+}
+
+// handlerTimeout 处理函数超时严格小于可见期，预留十分之一用于确认，避免任务在处理中被重新投递
+func handlerTimeout(visibility time.Duration) time.Duration {
+	return visibility - visibility/10
+}
+
+// backoff 指数退避
+func (m *Manager) backoff(attempt int) time.Duration {
+	d := time.Duration(float64(m.cfg.RetryMinBackoff) * math.Pow(2, float64(attempt-1)))
+	if d <= 0 || d > m.cfg.RetryMaxBackoff {
+		return m.cfg.RetryMaxBackoff
+	}
+	return d
+}
+
+// safeRun 执行处理函数并捕获panic
+func safeRun(ctx context.Context, h Handler, job *Job) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("job panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return h(ctx, job)
+}
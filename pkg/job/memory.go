@@ -0,0 +1,157 @@
+package job
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryBackend 进程内队列存储，用于测试和单实例部署（进程退出后任务丢失）
+type memoryBackend struct {
+	mu     sync.Mutex
+	queues map[string]*memoryQueue
+}
+
+type memoryQueue struct {
+	ready      []*Job
+	delayed    []*Job
+	processing map[string]processingJob
+	dead       []*Job
+}
+
+type processingJob struct {
+	job      *Job
+	deadline time.Time
+	lease    string
+}
+
+// NewMemoryBackend 创建进程内队列存储
+func NewMemoryBackend() Backend {
+	return &memoryBackend{queues: make(map[string]*memoryQueue)}
+}
+
+func (b *memoryBackend) queue(name string) *memoryQueue {
+	q, ok := b.queues[name]
+	if !ok {
+		q = &memoryQueue{processing: make(map[string]processingJob)}
+		b.queues[name] = q
+	}
+	return q
+}
+
+func (b *memoryBackend) Enqueue(_ context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.queue(job.Queue)
+	cp := *job
+	if cp.RunAt.After(time.Now()) {
+		q.delayed = append(q.delayed, &cp)
+	} else {
+		q.ready = append(q.ready, &cp)
+	}
+	return nil
+}
+
+func (b *memoryBackend) Dequeue(_ context.Context, queue string, visibility time.Duration) (*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.queue(queue)
+	if len(q.ready) == 0 {
+		return nil, nil
+	}
+	job := q.ready[0]
+	q.ready = q.ready[1:]
+	lease := newLease()
+	q.processing[job.ID] = processingJob{job: job, deadline: time.Now().Add(visibility), lease: lease}
+
+	cp := *job
+	cp.raw = lease
+	return &cp, nil
+}
+
+// release 仍持有租约时将任务移出处理中
+func (q *memoryQueue) release(job *Job) error {
+	p, ok := q.processing[job.ID]
+	if !ok || p.lease != job.raw {
+		return ErrLeaseLost
+	}
+	delete(q.processing, job.ID)
+	return nil
+}
+
+func (b *memoryBackend) Ack(_ context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	return b.queue(job.Queue).release(job)
+}
+
+func (b *memoryBackend) Retry(_ context.Context, job *Job, runAt time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.queue(job.Queue)
+	if err := q.release(job); err != nil {
+		return err
+	}
+	cp := *job
+	cp.RunAt = runAt
+	q.delayed = append(q.delayed, &cp)
+	return nil
+}
+
+func (b *memoryBackend) Kill(_ context.Context, job *Job) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.queue(job.Queue)
+	if err := q.release(job); err != nil {
+		return err
+	}
+	cp := *job
+	q.dead = append([]*Job{&cp}, q.dead...)
+	return nil
+}
+
+func (b *memoryBackend) Promote(_ context.Context, queue string, now time.Time) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	q := b.queue(queue)
+	pending := q.delayed[:0]
+	for _, job := range q.delayed {
+		if job.RunAt.After(now) {
+			pending = append(pending, job)
+			continue
+		}
+		q.ready = append(q.ready, job)
+	}
+	q.delayed = pending
+
+	for id, p := range q.processing {
+		if p.deadline.After(now) {
+			continue
+		}
+		delete(q.processing, id)
+		q.ready = append(q.ready, p.job)
+	}
+	return nil
+}
+
+func (b *memoryBackend) Dead(_ context.Context, queue string, limit int64) ([]*Job, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	dead := b.queue(queue).dead
+	if limit > 0 && int64(len(dead)) > limit {
+		dead = dead[:limit]
+	}
+	jobs := make([]*Job, 0, len(dead))
+	for _, job := range dead {
+		cp := *job
+		jobs = append(jobs, &cp)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,165 @@
+package job
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+)
+
+// 取出就绪任务并记录处理截止时间，processing 中的成员为租约令牌加原始编码，
+// 同一任务重新投递后成员不同，过期的处理者无法确认或重试新的投递
+var dequeueScript = goredis.NewScript(`
+local raw = redis.call("RPOP", KEYS[1])
+if raw then
+	redis.call("ZADD", KEYS[2], ARGV[1], ARGV[2] .. raw)
+end
+return raw
+`)
+
+// 将到期的延迟任务和超时未确认的任务（去掉租约令牌）移回就绪队列
+var promoteScript = goredis.NewScript(`
+local moved = 0
+for i = 1, 2 do
+	local due = redis.call("ZRANGEBYSCORE", KEYS[i], "-inf", ARGV[1], "LIMIT", 0, ARGV[2])
+	for _, member in ipairs(due) do
+		redis.call("ZREM", KEYS[i], member)
+		local raw = member
+		if i == 2 then
+			raw = string.sub(member, tonumber(ARGV[3]) + 1)
+		end
+		redis.call("LPUSH", KEYS[3], raw)
+		moved = moved + 1
+	end
+end
+return moved
+`)
+
+// 仍持有租约时将任务移出 processing 并写入目标队列，ARGV[2] 为分值时写入有序集合，否则写入列表
+var moveScript = goredis.NewScript(`
+if redis.call("ZREM", KEYS[1], ARGV[1]) == 0 then
+	return 0
+end
+if ARGV[2] ~= "" then
+	redis.call("ZADD", KEYS[2], ARGV[2], ARGV[3])
+else
+	redis.call("LPUSH", KEYS[2], ARGV[3])
+end
+return 1
+`)
+
+// promoteBatch 单次搬运的最大任务数
+const promoteBatch = 100
+
+// redisBackend 基于Redis的队列存储，同一队列的key使用相同hash tag以兼容集群模式
+type redisBackend struct {
+	cli goredis.UniversalClient
+}
+
+// NewRedisBackend 创建Redis队列存储
+func NewRedisBackend(client *redis.Client) Backend {
+	return &redisBackend{cli: client.GetClient()}
+}
+
+func key(queue, kind string) string {
+	return "job:{" + queue + "}:" + kind
+}
+
+func (b *redisBackend) Enqueue(ctx context.Context, job *Job) error {
+	raw, err := encode(job)
+	if err != nil {
+		return err
+	}
+	if job.RunAt.After(time.Now()) {
+		return b.cli.ZAdd(ctx, key(job.Queue, "delayed"), goredis.Z{
+			Score:  float64(job.RunAt.UnixMilli()),
+			Member: raw,
+		}).Err()
+	}
+	return b.cli.LPush(ctx, key(job.Queue, "ready"), raw).Err()
+}
+
+func (b *redisBackend) Dequeue(ctx context.Context, queue string, visibility time.Duration) (*Job, error) {
+	deadline := time.Now().Add(visibility).UnixMilli()
+	lease := newLease()
+	raw, err := dequeueScript.Run(ctx, b.cli,
+		[]string{key(queue, "ready"), key(queue, "processing")}, deadline, lease).Text()
+	if errors.Is(err, goredis.Nil) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	job, err := decode(raw)
+	if err != nil {
+		return nil, err
+	}
+	job.raw = lease + raw
+	return job, nil
+}
+
+func (b *redisBackend) Ack(ctx context.Context, job *Job) error {
+	n, err := b.cli.ZRem(ctx, key(job.Queue, "processing"), job.raw).Result()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (b *redisBackend) Retry(ctx context.Context, job *Job, runAt time.Time) error {
+	job.RunAt = runAt
+	raw, err := encode(job)
+	if err != nil {
+		return err
+	}
+	return b.move(ctx, job, "delayed", strconv.FormatInt(runAt.UnixMilli(), 10), raw)
+}
+
+func (b *redisBackend) Kill(ctx context.Context, job *Job) error {
+	raw, err := encode(job)
+	if err != nil {
+		return err
+	}
+	return b.move(ctx, job, "dead", "", raw)
+}
+
+func (b *redisBackend) move(ctx context.Context, job *Job, kind, score, raw string) error {
+	n, err := moveScript.Run(ctx, b.cli,
+		[]string{key(job.Queue, "processing"), key(job.Queue, kind)}, job.raw, score, raw).Int()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrLeaseLost
+	}
+	return nil
+}
+
+func (b *redisBackend) Promote(ctx context.Context, queue string, now time.Time) error {
+	return promoteScript.Run(ctx, b.cli,
+		[]string{key(queue, "delayed"), key(queue, "processing"), key(queue, "ready")},
+		strconv.FormatInt(now.UnixMilli(), 10), promoteBatch, leaseLen).Err()
+}
+
+func (b *redisBackend) Dead(ctx context.Context, queue string, limit int64) ([]*Job, error) {
+	raws, err := b.cli.LRange(ctx, key(queue, "dead"), 0, limit-1).Result()
+	if err != nil {
+		return nil, err
+	}
+	jobs := make([]*Job, 0, len(raws))
+	for _, raw := range raws {
+		job, err := decode(raw)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, job)
+	}
+	return jobs, nil
+}
@@ -0,0 +1,291 @@
+package lock
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math"
+	mrand "math/rand/v2"
+	"sync"
+	"time"
+)
+
+var (
+	// ErrNotObtained 锁已被其他持有者占用
+	ErrNotObtained = errors.New("lock: not obtained")
+	// ErrNotHeld 锁已过期或已被他人持有，无法续期或释放
+	ErrNotHeld = errors.New("lock: not held")
+)
+
+const (
+	// MinTTL 锁有效期下限，Redis按毫秒设置过期时间
+	MinTTL = time.Millisecond
+	// minRefreshInterval 自动续期间隔下限，避免TTL极短时续期过于频繁
+	minRefreshInterval = time.Millisecond
+)
+
+// checkTTL 校验锁有效期
+func checkTTL(ttl time.Duration) error {
+	if ttl < MinTTL {
+		return fmt.Errorf("lock: invalid ttl %s, must be at least %s", ttl, MinTTL)
+	}
+	return nil
+}
+
+// Locker 分布式锁
+type Locker interface {
+	// TryObtain 尝试获取一次锁，被占用时返回ErrNotObtained
+	TryObtain(ctx context.Context, key string, ttl time.Duration, opts ...Option) (Lock, error)
+	// Obtain 阻塞获取锁，按退避策略重试直到成功或ctx结束
+	Obtain(ctx context.Context, key string, ttl time.Duration, opts ...Option) (Lock, error)
+}
+
+// Lock 已持有的锁
+type Lock interface {
+	// Key 锁名称
+	Key() string
+	// Token 本次持有的唯一令牌
+	Token() string
+	// Fence 单调递增的隔离令牌，下游写入时可据此拒绝过期持有者
+	Fence() int64
+	// Refresh 手动续期
+	Refresh(ctx context.Context, ttl time.Duration) error
+	// Release 释放锁并停止自动续期
+	Release(ctx context.Context) error
+	// Lost 自动续期失败（锁已丢失）时关闭
+	Lost() <-chan struct{}
+}
+
+// RetryStrategy 重试退避策略，返回下一次等待时长
+type RetryStrategy func(attempt int) time.Duration
+
+// ExponentialBackoff 指数退避（带抖动）
+func ExponentialBackoff(min, max time.Duration) RetryStrategy {
+	return func(attempt int) time.Duration {
+		d := time.Duration(float64(min) * math.Pow(2, float64(attempt)))
+		if d <= 0 || d > max {
+			d = max
+		}
+		// 抖动范围 [d/2, d)
+		half := int64(d / 2)
+		if half <= 0 {
+			return d
+		}
+		return time.Duration(half + mrand.Int64N(half))
+	}
+}
+
+// LinearBackoff 固定间隔重试
+func LinearBackoff(interval time.Duration) RetryStrategy {
+	return func(int) time.Duration { return interval }
+}
+
+type options struct {
+	retry      RetryStrategy
+	autoExtend bool
+	onLost     func(key string)
+}
+
+// Option 获取锁选项
+type Option func(*options)
+
+// WithRetry 自定义Obtain的重试策略
+func WithRetry(strategy RetryStrategy) Option {
+	return func(o *options) { o.retry = strategy }
+}
+
+// WithoutAutoExtend 关闭自动续期（锁在TTL后自动过期）
+func WithoutAutoExtend() Option {
+	return func(o *options) { o.autoExtend = false }
+}
+
+// WithOnLost 自动续期失败时的回调
+func WithOnLost(fn func(key string)) Option {
+	return func(o *options) { o.onLost = fn }
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		retry:      ExponentialBackoff(10*time.Millisecond, time.Second),
+		autoExtend: true,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// backend 存储实现（Redis/内存）
+type backend interface {
+	obtain(ctx context.Context, key, token string, ttl time.Duration) (fence int64, ok bool, err error)
+	refresh(ctx context.Context, key, token string, ttl time.Duration) (bool, error)
+	release(ctx context.Context, key, token string) (bool, error)
+}
+
+// locker 基于backend的通用Locker实现
+type locker struct {
+	backend backend
+}
+
+func (l *locker) TryObtain(ctx context.Context, key string, ttl time.Duration, opts ...Option) (Lock, error) {
+	if err := checkTTL(ttl); err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+
+	token, err := newToken()
+	if err != nil {
+		return nil, err
+	}
+
+	fence, ok, err := l.backend.obtain(ctx, key, token, ttl)
+	if err != nil {
+		return nil, fmt.Errorf("lock: obtain %q: %w", key, err)
+	}
+	if !ok {
+		return nil, ErrNotObtained
+	}
+
+	h := &held{
+		backend: l.backend,
+		key:     key,
+		token:   token,
+		fence:   fence,
+		ttl:     ttl,
+		lost:    make(chan struct{}),
+		stop:    make(chan struct{}),
+		done:    make(chan struct{}),
+	}
+	if o.autoExtend {
+		go h.keepAlive(o.onLost)
+	} else {
+		close(h.done)
+	}
+	return h, nil
+}
+
+func (l *locker) Obtain(ctx context.Context, key string, ttl time.Duration, opts ...Option) (Lock, error) {
+	if err := checkTTL(ttl); err != nil {
+		return nil, err
+	}
+	o := newOptions(opts)
+	for attempt := 0; ; attempt++ {
+		lk, err := l.TryObtain(ctx, key, ttl, opts...)
+		if !errors.Is(err, ErrNotObtained) {
+			return lk, err
+		}
+
+		timer := time.NewTimer(o.retry(attempt))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("lock: obtain %q: %w", key, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
+
+// held 已获取的锁
+type held struct {
+	backend backend
+	key     string
+	token   string
+	fence   int64
+	ttl     time.Duration
+
+	mu       sync.Mutex
+	released bool
+	lostOnce sync.Once
+	lost     chan struct{}
+	stop     chan struct{}
+	done     chan struct{}
+}
+
+func (h *held) Key() string           { return h.key }
+func (h *held) Token() string         { return h.token }
+func (h *held) Fence() int64          { return h.fence }
+func (h *held) Lost() <-chan struct{} { return h.lost }
+
+func (h *held) Refresh(ctx context.Context, ttl time.Duration) error {
+	if err := checkTTL(ttl); err != nil {
+		return err
+	}
+	ok, err := h.backend.refresh(ctx, h.key, h.token, ttl)
+	if err != nil {
+		return fmt.Errorf("lock: refresh %q: %w", h.key, err)
+	}
+	if !ok {
+		h.markLost()
+		return ErrNotHeld
+	}
+	return nil
+}
+
+func (h *held) Release(ctx context.Context) error {
+	h.mu.Lock()
+	if h.released {
+		h.mu.Unlock()
+		return nil
+	}
+	h.released = true
+	close(h.stop)
+	h.mu.Unlock()
+	<-h.done
+
+	ok, err := h.backend.release(ctx, h.key, h.token)
+	if err != nil {
+		return fmt.Errorf("lock: release %q: %w", h.key, err)
+	}
+	if !ok {
+		return ErrNotHeld
+	}
+	return nil
+}
+
+// keepAlive 每1/3 TTL（不低于minRefreshInterval）续期一次，直到释放或续期失败
+func (h *held) keepAlive(onLost func(string)) {
+	defer close(h.done)
+
+	interval := max(h.ttl/3, minRefreshInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastOK := time.Now()
+	for {
+		select {
+		case <-h.stop:
+			return
+		case <-ticker.C:
+			ctx, cancel := context.WithTimeout(context.Background(), interval)
+			ok, err := h.backend.refresh(ctx, h.key, h.token, h.ttl)
+			cancel()
+			if err == nil && ok {
+				lastOK = time.Now()
+				continue
+			}
+			// 网络抖动时保留到下次重试，锁确实丢失或已超过TTL未续期时通知
+			if err == nil || time.Since(lastOK) >= h.ttl {
+				h.markLost()
+				if onLost != nil {
+					onLost(h.key)
+				}
+				return
+			}
+		}
+	}
+}
+
+func (h *held) markLost() {
+	h.lostOnce.Do(func() { close(h.lost) })
+}
+
+// newToken 生成随机令牌
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("lock: generate token: %w", err)
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,189 @@
+package lock
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// lockers 分别基于内存和miniredis的实现
+func lockers(t *testing.T) map[string]Locker {
+	t.Helper()
+	mr := miniredis.RunT(t)
+	cli := goredis.NewClient(&goredis.Options{Addr: mr.Addr()})
+	t.Cleanup(func() { _ = cli.Close() })
+
+	return map[string]Locker{
+		"memory": NewMemory(),
+		"redis":  &locker{backend: &redisBackend{cli: cli, prefix: "lock:"}},
+	}
+}
+
+func TestInvalidTTL(t *testing.T) {
+	ctx := context.Background()
+	for name, l := range lockers(t) {
+		t.Run(name, func(t *testing.T) {
+			for _, ttl := range []time.Duration{0, -time.Second, time.Nanosecond} {
+				if _, err := l.TryObtain(ctx, "job", ttl); err == nil {
+					t.Fatalf("TryObtain(ttl=%s) succeeded, want error", ttl)
+				}
+				if _, err := l.Obtain(ctx, "job", ttl); err == nil {
+					t.Fatalf("Obtain(ttl=%s) succeeded, want error", ttl)
+				}
+			}
+		})
+	}
+}
+
+func TestExclusiveAndFence(t *testing.T) {
+	ctx := context.Background()
+	for name, l := range lockers(t) {
+		t.Run(name, func(t *testing.T) {
+			first, err := l.TryObtain(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("TryObtain: %v", err)
+			}
+			if _, err := l.TryObtain(ctx, "job", time.Second); !errors.Is(err, ErrNotObtained) {
+				t.Fatalf("second TryObtain = %v, want ErrNotObtained", err)
+			}
+			if err := first.Release(ctx); err != nil {
+				t.Fatalf("Release: %v", err)
+			}
+
+			second, err := l.TryObtain(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("TryObtain after release: %v", err)
+			}
+			defer second.Release(ctx)
+			if second.Fence() <= first.Fence() {
+				t.Fatalf("fence %d not greater than %d", second.Fence(), first.Fence())
+			}
+			if second.Token() == first.Token() {
+				t.Fatal("tokens must differ between holders")
+			}
+		})
+	}
+}
+
+func TestObtainWaitsForRelease(t *testing.T) {
+	ctx := context.Background()
+	for name, l := range lockers(t) {
+		t.Run(name, func(t *testing.T) {
+			held, err := l.TryObtain(ctx, "job", time.Second)
+			if err != nil {
+				t.Fatalf("TryObtain: %v", err)
+			}
+			time.AfterFunc(50*time.Millisecond, func() { _ = held.Release(ctx) })
+
+			ctx, cancel := context.WithTimeout(ctx, 2*time.Second)
+			defer cancel()
+			lk, err := l.Obtain(ctx, "job", time.Second, WithRetry(LinearBackoff(10*time.Millisecond)))
+			if err != nil {
+				t.Fatalf("Obtain: %v", err)
+			}
+			_ = lk.Release(ctx)
+		})
+	}
+}
+
+func TestObtainContextCanceled(t *testing.T) {
+	l := NewMemory()
+	held, err := l.TryObtain(context.Background(), "job", time.Second)
+	if err != nil {
+		t.Fatalf("TryObtain: %v", err)
+	}
+	defer held.Release(context.Background())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Millisecond)
+	defer cancel()
+	if _, err := l.Obtain(ctx, "job", time.Second); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Obtain = %v, want context.DeadlineExceeded", err)
+	}
+}
+
+func TestAutoExtend(t *testing.T) {
+	l := NewMemory()
+	lk, err := l.TryObtain(context.Background(), "job", 30*time.Millisecond)
+	if err != nil {
+		t.Fatalf("TryObtain: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+
+	if _, err := l.TryObtain(context.Background(), "job", time.Second); !errors.Is(err, ErrNotObtained) {
+		t.Fatalf("lock expired despite auto extend: %v", err)
+	}
+	if err := lk.Release(context.Background()); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+}
+
+func TestWithoutAutoExtendExpires(t *testing.T) {
+	l := NewMemory()
+	lk, err := l.TryObtain(context.Background(), "job", 20*time.Millisecond, WithoutAutoExtend())
+	if err != nil {
+		t.Fatalf("TryObtain: %v", err)
+	}
+	time.Sleep(40 * time.Millisecond)
+
+	other, err := l.TryObtain(context.Background(), "job", time.Second)
+	if err != nil {
+		t.Fatalf("TryObtain after expiry: %v", err)
+	}
+	defer other.Release(context.Background())
+	if err := lk.Release(context.Background()); !errors.Is(err, ErrNotHeld) {
+		t.Fatalf("Release of expired lock = %v, want ErrNotHeld", err)
+	}
+	if err := lk.Refresh(context.Background(), time.Second); !errors.Is(err, ErrNotHeld) {
+		t.Fatalf("Refresh of expired lock = %v, want ErrNotHeld", err)
+	}
+	select {
+	case <-lk.Lost():
+	default:
+		t.Fatal("Lost() not closed after failed refresh")
+	}
+}
+
+func TestLostNotifiesOnLost(t *testing.T) {
+	mb := &memoryBackend{locks: map[string]memoryEntry{}, fences: map[string]int64{}, now: time.Now}
+	l := &locker{backend: mb}
+
+	lost := make(chan string, 1)
+	lk, err := l.TryObtain(context.Background(), "job", 30*time.Millisecond, WithOnLost(func(key string) { lost <- key }))
+	if err != nil {
+		t.Fatalf("TryObtain: %v", err)
+	}
+	// 模拟锁被他人抢占
+	mb.mu.Lock()
+	mb.locks["job"] = memoryEntry{token: "other", expires: time.Now().Add(time.Hour)}
+	mb.mu.Unlock()
+
+	select {
+	case key := <-lost:
+		if key != "job" {
+			t.Fatalf("onLost key = %q", key)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("onLost not called")
+	}
+	<-lk.Lost()
+}
+
+func TestNew(t *testing.T) {
+	log := logger.NewNop()
+	if l, err := New(&config.Config{Lock: config.LockConfig{Backend: "redis"}}, nil, log); err != nil || l != nil {
+		t.Fatalf("redis backend without REDIS: New() = %v, %v; want nil, nil", l, err)
+	}
+	if l, err := New(&config.Config{Lock: config.LockConfig{Backend: "memory"}}, nil, log); err != nil || l == nil {
+		t.Fatalf("memory backend: New() = %v, %v", l, err)
+	}
+	if _, err := New(&config.Config{Lock: config.LockConfig{Backend: "etcd"}}, nil, log); err == nil {
+		t.Fatal("unknown backend should fail")
+	}
+}
@@ -0,0 +1,69 @@
+package lock
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryBackend 进程内锁存储，用于测试和单实例部署
+type memoryBackend struct {
+	mu     sync.Mutex
+	locks  map[string]memoryEntry
+	fences map[string]int64
+	now    func() time.Time
+}
+
+type memoryEntry struct {
+	token   string
+	expires time.Time
+}
+
+// NewMemory 创建进程内锁
+func NewMemory() Locker {
+	return &locker{backend: &memoryBackend{
+		locks:  make(map[string]memoryEntry),
+		fences: make(map[string]int64),
+		now:    time.Now,
+	}}
+}
+
+func (b *memoryBackend) obtain(_ context.Context, key, token string, ttl time.Duration) (int64, bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	if e, ok := b.locks[key]; ok && now.Before(e.expires) {
+		return 0, false, nil
+	}
+
+	b.locks[key] = memoryEntry{token: token, expires: now.Add(ttl)}
+	b.fences[key]++
+	return b.fences[key], true, nil
+}
+
+func (b *memoryBackend) refresh(_ context.Context, key, token string, ttl time.Duration) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	now := b.now()
+	e, ok := b.locks[key]
+	if !ok || e.token != token || !now.Before(e.expires) {
+		return false, nil
+	}
+	e.expires = now.Add(ttl)
+	b.locks[key] = e
+	return true, nil
+}
+
+func (b *memoryBackend) release(_ context.Context, key, token string) (bool, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	e, ok := b.locks[key]
+	if !ok || e.token != token || !b.now().Before(e.expires) {
+		return false, nil
+	}
+	delete(b.locks, key)
+	return true, nil
+}
@@ -0,0 +1,16 @@
+package logger
+
+import "context"
+
+type requestIDKey struct{}
+
+// ContextWithRequestID 将请求ID写入上下文，由HTTP中间件在请求入口写入
+func ContextWithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey{}, id)
+}
+
+// RequestIDFromContext 读取请求ID，供日志、审计、SQL日志等下游组件关联请求，不依赖HTTP层
+func RequestIDFromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey{}).(string)
+	return id
+}
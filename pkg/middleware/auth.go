@@ -1,26 +1,51 @@
 package middleware
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/secret"
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 )
 
+// ErrAuthDisabled 未启用JWT（JWT.ENABLED=false）时无法签发或校验令牌
+var ErrAuthDisabled = errors.New("auth: jwt is not enabled (JWT.ENABLED=false)")
+
 // Auth JWT认证中间件
 type Auth struct {
 	cfg *config.AuthConfig
+
+	mu       sync.RWMutex
+	secret   []byte
+	previous []byte
 }
 
-// NewAuth 构造函数
-func NewAuth(cfg *config.Config) *Auth {
+// NewAuth 构造函数，JWT.SECRET 通过密钥引用加载时随轮换更新
+func NewAuth(cfg *config.Config, secrets *secret.Watcher) *Auth {
 	if !cfg.JWT.Enabled {
 		return nil
 	}
-	return &Auth{cfg: &cfg.JWT}
+	a := &Auth{cfg: &cfg.JWT, secret: []byte(cfg.JWT.Secret)}
+	secrets.OnChange("JWT.SECRET", a.rotate)
+	return a
+}
+
+// rotate 更换签名密钥，上一个密钥签发的令牌在过期前仍可通过校验
+func (a *Auth) rotate(key string) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.previous, a.secret = a.secret, []byte(key)
+}
+
+func (a *Auth) keys() (current, previous []byte) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	return a.secret, a.previous
 }
 
 // Middleware 生成Gin中间件
@@ -36,22 +61,26 @@ func (a *Auth) Middleware() gin.HandlerFunc {
 			return
 		}
 
-		claims, err := a.parseToken(tokenString)
+		claims, err := a.ParseToken(tokenString)
 		if err != nil {
 			abortWithError(c, http.StatusUnauthorized, "Invalid or expired token")
 			return
 		}
 
-		// 存储claims到上下文
+		// 存储claims到上下文，请求context中同样保存一份供Service/DAO层读取
 		for k, v := range claims {
 			c.Set(k, v)
 		}
+		c.Request = c.Request.WithContext(ContextWithClaims(c.Request.Context(), claims))
 		c.Next()
 	}
 }
 
 // GenerateToken 生成JWT令牌 (供Service层调用)
 func (a *Auth) GenerateToken(userID uint, extraClaims map[string]interface{}) (string, error) {
+	if a == nil {
+		return "", ErrAuthDisabled
+	}
 	claims := jwt.MapClaims{
 		"userID": userID,
 		"exp":    time.Now().Add(a.cfg.ExpireDuration).Unix(),
@@ -61,16 +90,29 @@ func (a *Auth) GenerateToken(userID uint, extraClaims map[string]interface{}) (s
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	return token.SignedString([]byte(a.cfg.Secret))
+	current, _ := a.keys()
+	return token.SignedString(current)
+}
+
+// ParseToken 解析并校验JWT令牌（HTTP、gRPC、WebSocket等入口共用）
+func (a *Auth) ParseToken(tokenString string) (jwt.MapClaims, error) {
+	if a == nil {
+		return nil, ErrAuthDisabled
+	}
+	current, previous := a.keys()
+	claims, err := parseToken(tokenString, current)
+	if err != nil && previous != nil && errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		return parseToken(tokenString, previous)
+	}
+	return claims, err
 }
 
-// parseToken 解析JWT令牌
-func (a *Auth) parseToken(tokenString string) (jwt.MapClaims, error) {
+func parseToken(tokenString string, key []byte) (jwt.MapClaims, error) {
 	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(a.cfg.Secret), nil
+		return key, nil
 	})
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
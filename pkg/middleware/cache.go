@@ -0,0 +1,55 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// CacheControl 为成功的 GET/HEAD 响应设置 Cache-Control: max-age，错误响应不缓存
+func CacheControl(ttl time.Duration, private bool) gin.HandlerFunc {
+	scope := "public"
+	if private {
+		scope = "private"
+	}
+	value := fmt.Sprintf("%s, max-age=%d", scope, int(ttl.Seconds()))
+
+	return func(c *gin.Context) {
+		if c.Request.Method != http.MethodGet && c.Request.Method != http.MethodHead {
+			c.Next()
+			return
+		}
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: value}
+		c.Next()
+	}
+}
+
+// cacheControlWriter 在写入状态码时按结果决定是否附加 Cache-Control
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	w.apply(code)
+	w.ResponseWriter.WriteHeader(code)
+}
+
+// Write 未显式设置状态码时按当前状态（默认200）处理
+func (w *cacheControlWriter) Write(data []byte) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *cacheControlWriter) WriteString(s string) (int, error) {
+	w.apply(w.Status())
+	return w.ResponseWriter.WriteString(s)
+}
+
+func (w *cacheControlWriter) apply(code int) {
+	if !w.Written() && code >= 200 && code < 300 && w.Header().Get("Cache-Control") == "" {
+		w.Header().Set("Cache-Control", w.value)
+	}
+}
@@ -0,0 +1,132 @@
+package middleware
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+type claimsKey struct{}
+
+// 健康检查与反射服务无需认证
+var publicGRPCPrefixes = []string{
+	"/grpc.health.v1.Health/",
+	"/grpc.reflection.",
+}
+
+// ContextWithClaims 将JWT claims写入上下文
+func ContextWithClaims(ctx context.Context, claims jwt.MapClaims) context.Context {
+	return context.WithValue(ctx, claimsKey{}, claims)
+}
+
+// ClaimsFromContext 读取认证通过后写入请求上下文的JWT claims（HTTP与gRPC通用）
+func ClaimsFromContext(ctx context.Context) (jwt.MapClaims, bool) {
+	claims, ok := ctx.Value(claimsKey{}).(jwt.MapClaims)
+	return claims, ok
+}
+
+// UnaryInterceptor 生成gRPC一元调用认证拦截器
+func (a *Auth) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		ctx, err := a.authenticate(ctx, info.FullMethod)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor 生成gRPC流式调用认证拦截器
+func (a *Auth) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := a.authenticate(ss.Context(), info.FullMethod)
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// authenticate 从metadata的authorization中解析令牌
+func (a *Auth) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if a == nil || isPublicGRPCMethod(method) {
+		return ctx, nil
+	}
+
+	var tokenString string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if values := md.Get("authorization"); len(values) > 0 {
+			tokenString = values[0]
+		}
+	}
+	if len(tokenString) > 7 && strings.ToUpper(tokenString[0:6]) == "BEARER" {
+		tokenString = tokenString[7:]
+	}
+	if tokenString == "" {
+		return nil, status.Error(codes.Unauthenticated, "authorization metadata required")
+	}
+
+	claims, err := a.ParseToken(tokenString)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired token")
+	}
+	return ContextWithClaims(ctx, claims), nil
+}
+
+// UnaryInterceptor 生成gRPC一元调用限流拦截器
+func (r *RateLimiter) UnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if !r.Allow(peerIP(ctx)) {
+			return nil, status.Error(codes.ResourceExhausted, "too many requests")
+		}
+		return handler(ctx, req)
+	}
+}
+
+// StreamInterceptor 生成gRPC流式调用限流拦截器（按建立流计数）
+func (r *RateLimiter) StreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !r.Allow(peerIP(ss.Context())) {
+			return status.Error(codes.ResourceExhausted, "too many requests")
+		}
+		return handler(srv, ss)
+	}
+}
+
+// contextStream 替换流的上下文以传递claims
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextStream) Context() context.Context {
+	return s.ctx
+}
+
+func isPublicGRPCMethod(method string) bool {
+	for _, prefix := range publicGRPCPrefixes {
+		if strings.HasPrefix(method, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// peerIP 获取调用方IP
+func peerIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil {
+		return ""
+	}
+	if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+		return host
+	}
+	return p.Addr.String()
+}
@@ -0,0 +1,58 @@
+package middleware
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// PermissionsClaim 令牌中保存权限列表的claim，签发时通过 GenerateToken 的 extraClaims 写入：
+//
+//	auth.GenerateToken(user.ID, map[string]interface{}{middleware.PermissionsClaim: []string{"user:read"}})
+const PermissionsClaim = "permissions"
+
+// RequirePermissions 生成权限校验中间件，需在 Middleware 之后执行；令牌需包含全部权限，"*" 表示拥有所有权限。
+// 未启用JWT时无法校验权限，拒绝全部请求
+func (a *Auth) RequirePermissions(perms ...string) gin.HandlerFunc {
+	if len(perms) == 0 {
+		return func(c *gin.Context) { c.Next() }
+	}
+	if a == nil {
+		return func(c *gin.Context) {
+			abortWithError(c, http.StatusForbidden, "Permission denied: JWT is disabled")
+		}
+	}
+
+	return func(c *gin.Context) {
+		claims, _ := ClaimsFromContext(c.Request.Context())
+		granted := permissions(claims)
+		if !granted["*"] {
+			for _, p := range perms {
+				if !granted[p] {
+					abortWithError(c, http.StatusForbidden, "Permission denied: "+p)
+					return
+				}
+			}
+		}
+		c.Next()
+	}
+}
+
+// permissions 读取claims中的权限列表（JSON解码后为 []interface{}）
+func permissions(claims jwt.MapClaims) map[string]bool {
+	granted := map[string]bool{}
+	switch list := claims[PermissionsClaim].(type) {
+	case []interface{}:
+		for _, p := range list {
+			if s, ok := p.(string); ok {
+				granted[s] = true
+			}
+		}
+	case []string:
+		for _, p := range list {
+			granted[p] = true
+		}
+	}
+	return granted
+}
@@ -1,6 +1,7 @@
 package middleware
 
 import (
+	"fmt"
 	"github.com/gin-gonic/gin"
 	"github.com/mjcode-max/TurboGin/config"
 	"golang.org/x/time/rate"
@@ -15,18 +16,17 @@ type RateLimiter struct {
 	rps      float64
 	burst    int
 	enabled  bool
+	policies map[string]config.RateLimitPolicy
 }
 
-// NewRateLimiter 构造函数
+// NewRateLimiter 构造函数；未开启限流时返回不限流的实例，仍按配置校验路由引用的策略名称
 func NewRateLimiter(cfg *config.Config) *RateLimiter {
-	if !cfg.Middleware.RateLimit.Enabled {
-		return nil
-	}
 	return &RateLimiter{
 		limiters: make(map[string]*rate.Limiter),
 		rps:      cfg.Middleware.RateLimit.RPS,
 		burst:    cfg.Middleware.RateLimit.Burst,
-		enabled:  true,
+		enabled:  cfg.Middleware.RateLimit.Enabled,
+		policies: cfg.Middleware.RateLimit.Policies,
 	}
 }
 
@@ -37,28 +37,67 @@ func (r *RateLimiter) Middleware() gin.HandlerFunc {
 	}
 
 	return func(c *gin.Context) {
-		limiter := r.getLimiter(c.ClientIP())
-		if !limiter.Allow() {
-			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
-				"error": "too many requests",
-				"code":  http.StatusTooManyRequests,
-			})
+		if !r.getLimiter(c.ClientIP()).Allow() {
+			abortTooManyRequests(c)
 			return
 		}
 		c.Next()
 	}
 }
 
-// getLimiter 获取或创建限流器
+// Policy 生成具名策略（MIDDLEWARE.RATE_LIMIT.POLICIES）的中间件，与全局限流分别计数；
+// 策略不存在（或r为nil，无配置可查）时返回错误，未开启限流时直接放行
+func (r *RateLimiter) Policy(name string) (gin.HandlerFunc, error) {
+	if r == nil {
+		return nil, fmt.Errorf("ratelimit: unknown policy %q: no rate limit configuration", name)
+	}
+	policy, ok := r.policies[name]
+	if !ok {
+		return nil, fmt.Errorf("ratelimit: unknown policy %q", name)
+	}
+	if !r.enabled {
+		return func(c *gin.Context) { c.Next() }, nil
+	}
+
+	return func(c *gin.Context) {
+		if !r.limiter(name+":"+c.ClientIP(), policy.RPS, policy.Burst).Allow() {
+			abortTooManyRequests(c)
+			return
+		}
+		c.Next()
+	}, nil
+}
+
+func abortTooManyRequests(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{
+		"error": "too many requests",
+		"code":  http.StatusTooManyRequests,
+	})
+}
+
+// Allow 判断指定来源是否允许通过（供非HTTP入口复用）
+func (r *RateLimiter) Allow(key string) bool {
+	if r == nil || !r.enabled {
+		return true
+	}
+	return r.getLimiter(key).Allow()
+}
+
+// getLimiter 获取或创建全局限流器
 func (r *RateLimiter) getLimiter(ip string) *rate.Limiter {
+	return r.limiter(ip, r.rps, r.burst)
+}
+
+// limiter 获取或创建限流器
+func (r *RateLimiter) limiter(key string, rps float64, burst int) *rate.Limiter {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	if limiter, exists := r.limiters[ip]; exists {
+	if limiter, exists := r.limiters[key]; exists {
 		return limiter
 	}
 
-	limiter := rate.NewLimiter(rate.Limit(r.rps), r.burst)
-	r.limiters[ip] = limiter
+	limiter := rate.NewLimiter(rate.Limit(rps), burst)
+	r.limiters[key] = limiter
 	return limiter
 }
@@ -0,0 +1,35 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// RequestIDHeader 请求ID请求/响应头
+const RequestIDHeader = "X-Request-ID"
+
+// RequestID 生成Gin中间件：沿用上游传入的X-Request-ID，否则生成新的ID，
+// 同时写入响应头、gin上下文和请求context，供日志、审计等下游组件关联
+func RequestID() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id := c.GetHeader(RequestIDHeader)
+		if id == "" || len(id) > 128 {
+			id = newRequestID()
+		}
+
+		c.Set("request_id", id)
+		c.Header(RequestIDHeader, id)
+		c.Request = c.Request.WithContext(logger.ContextWithRequestID(c.Request.Context(), id))
+		c.Next()
+	}
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -38,6 +38,7 @@ func (r *RequestLog) Middleware() gin.HandlerFunc {
 			logger.String("path", path),
 			logger.String("query", query),
 			logger.String("ip", c.ClientIP()),
+			logger.String("request_id", c.GetString("request_id")),
 			logger.String("user-agent", c.Request.UserAgent()),
 			logger.Duration("latency", latency),
 			logger.String("time", end.Format(time.RFC3339)),
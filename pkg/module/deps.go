@@ -0,0 +1,82 @@
+package module
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
+	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/job"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"gorm.io/gorm"
+)
+
+// ErrNotProvided 容器中没有所需类型的组件（提供方模块未注册、被禁用或注册顺序靠后）
+var ErrNotProvided = errors.New("module: component not provided")
+
+// Deps 模块可用的系统组件，未启用的组件为nil；模块之间通过 Provide/Resolve 按类型共享组件
+type Deps struct {
+	Config    *config.Config
+	Logger    *logger.Logger
+	DB        *gorm.DB
+	Sources   *db.Sources // DATABASE.SOURCES 中的具名数据源，通过 Sources.Get(name) 获取
+	Redis     *redis.Client
+	Auth      *middleware.Auth
+	Jobs      *job.Manager
+	Scheduler *scheduler.Scheduler
+	Hub       *realtime.Hub
+	Auditor   *audit.Auditor // 审计插件在模块构建前已注册到DB，模块初始化时的写入同样会被审计
+
+	mu     sync.RWMutex
+	values map[reflect.Type]interface{}
+}
+
+// Provide 以类型 T 为键放入组件，接口类型需显式指定，例如 module.Provide[service.IUserService](d, svc)
+func Provide[T any](d *Deps, v T) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.values == nil {
+		d.values = make(map[reflect.Type]interface{})
+	}
+	d.values[reflect.TypeOf((*T)(nil)).Elem()] = v
+}
+
+// Resolve 按类型取出其他模块提供的组件
+func Resolve[T any](d *Deps) (T, error) {
+	var zero T
+	t := reflect.TypeOf((*T)(nil)).Elem()
+
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+	v, ok := d.values[t]
+	if !ok {
+		return zero, fmt.Errorf("%w: %s", ErrNotProvided, t)
+	}
+	return v.(T), nil
+}
+
+// enabled 组件名称到是否启用的映射，用于校验 Requirer
+func (d *Deps) enabled(component string) (bool, error) {
+	switch component {
+	case "DATABASE":
+		return d.DB != nil, nil
+	case "REDIS":
+		return d.Redis != nil, nil
+	case "JWT":
+		return d.Auth != nil, nil
+	case "JOB":
+		return d.Jobs != nil, nil
+	case "SCHEDULER":
+		return d.Scheduler != nil, nil
+	case "REALTIME":
+		return d.Hub != nil, nil
+	}
+	return false, fmt.Errorf("unknown component %q", component)
+}
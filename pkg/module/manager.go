@@ -0,0 +1,193 @@
+package module
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/audit"
+	"github.com/mjcode-max/TurboGin/pkg/db"
+	"github.com/mjcode-max/TurboGin/pkg/job"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/realtime"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+	"github.com/mjcode-max/TurboGin/pkg/scheduler"
+	"google.golang.org/grpc"
+	"gorm.io/gorm"
+)
+
+// GRPCRegistrar 可选接口，模块在此注册gRPC服务（SERVER.GRPC.ENABLED开启时调用）
+type GRPCRegistrar interface {
+	RegisterGRPC(s *grpc.Server)
+}
+
+// Manager 已启用的模块
+type Manager struct {
+	cfg     *config.ModulesConfig
+	deps    *Deps
+	modules []Module
+	models  []interface{}
+}
+
+// New 构造函数：过滤 MODULES.DISABLED 中的模块，校验依赖后依次执行 Providers、迁移模型并注册生命周期钩子
+func New(
+	cfg *config.Config,
+	lc *app.Lifecycle,
+	log *logger.Logger,
+	db *gorm.DB,
+	sources *db.Sources,
+	rdb *redis.Client,
+	auth *middleware.Auth,
+	jobs *job.Manager,
+	sched *scheduler.Scheduler,
+	hub *realtime.Hub,
+	auditor *audit.Auditor,
+) (*Manager, error) {
+	log = log.WithFields(logger.String("component", "module"))
+	m := &Manager{
+		cfg: &cfg.Modules,
+		deps: &Deps{
+			Config:    cfg,
+			Logger:    log,
+			DB:        db,
+			Sources:   sources,
+			Redis:     rdb,
+			Auth:      auth,
+			Jobs:      jobs,
+			Scheduler: sched,
+			Hub:       hub,
+			Auditor:   auditor,
+		},
+	}
+
+	registered := Registered()
+	for _, name := range cfg.Modules.Disabled {
+		if !slices.ContainsFunc(registered, func(mod Module) bool { return mod.Name() == name }) {
+			log.Warn("Unknown module in MODULES.DISABLED", logger.String("module", name))
+		}
+	}
+	for _, mod := range enabled(&cfg.Modules, registered) {
+		if err := m.deps.check(mod); err != nil {
+			return nil, err
+		}
+		m.modules = append(m.modules, mod)
+	}
+
+	for _, mod := range m.modules {
+		for _, provide := range mod.Providers() {
+			if err := provide(m.deps); err != nil {
+				return nil, fmt.Errorf("module %s: %w", mod.Name(), err)
+			}
+		}
+		m.models = append(m.models, mod.Migrations()...)
+	}
+
+	if cfg.Modules.AutoMigrate && db != nil && len(m.models) > 0 {
+		if err := db.AutoMigrate(m.models...); err != nil {
+			return nil, fmt.Errorf("module: migrate: %w", err)
+		}
+	}
+
+	for _, mod := range m.modules {
+		lc.Append(app.Hook{
+			Name:    "module:" + mod.Name(),
+			OnStart: mod.OnStart,
+			OnStop:  mod.OnStop,
+		})
+	}
+
+	log.Info("Modules loaded", logger.Strings("modules", m.Names()))
+	return m, nil
+}
+
+// check 校验模块声明的依赖组件均已启用
+func (d *Deps) check(mod Module) error {
+	r, ok := mod.(Requirer)
+	if !ok {
+		return nil
+	}
+	for _, component := range r.Requires() {
+		enabled, err := d.enabled(component)
+		if err != nil {
+			return fmt.Errorf("module %s: %w", mod.Name(), err)
+		}
+		if !enabled {
+			return fmt.Errorf("module %s requires %s (%s.ENABLED=false), enable it or add %q to MODULES.DISABLED",
+				mod.Name(), component, component, mod.Name())
+		}
+	}
+	return nil
+}
+
+// Names 已启用模块的名称
+func (m *Manager) Names() []string {
+	if m == nil {
+		return nil
+	}
+	names := make([]string, len(m.modules))
+	for i, mod := range m.modules {
+		names[i] = mod.Name()
+	}
+	return names
+}
+
+// Models 已启用模块声明的模型（Migrations）
+func (m *Manager) Models() []interface{} {
+	if m == nil {
+		return nil
+	}
+	return append([]interface{}(nil), m.models...)
+}
+
+// Deps 模块共享的组件容器，可用于在模块之外取出模块提供的组件
+func (m *Manager) Deps() *Deps {
+	if m == nil {
+		return &Deps{}
+	}
+	return m.deps
+}
+
+// RegisterRoutes 在 MODULES.ROUTE_PREFIX 分组下依次注册各模块的路由
+func (m *Manager) RegisterRoutes(r *route.Router) {
+	if m == nil {
+		return
+	}
+	registerRoutes(m.cfg, m.modules, r)
+}
+
+// Routes 不构建模块组件，仅登记已启用模块的路由元数据，供 turbo routes 等离线生成路由表
+func Routes(cfg *config.ModulesConfig, r *route.Router) {
+	registerRoutes(cfg, enabled(cfg, Registered()), r)
+}
+
+func registerRoutes(cfg *config.ModulesConfig, modules []Module, r *route.Router) {
+	for _, mod := range modules {
+		mod.RegisterRoutes(r.Group(cfg.RoutePrefix).Tag(mod.Name()))
+	}
+}
+
+// enabled 按注册顺序返回未被 MODULES.DISABLED 禁用的模块
+func enabled(cfg *config.ModulesConfig, registered []Module) []Module {
+	var modules []Module
+	for _, mod := range registered {
+		if !slices.Contains(cfg.Disabled, mod.Name()) {
+			modules = append(modules, mod)
+		}
+	}
+	return modules
+}
+
+// RegisterGRPC 注册实现了 GRPCRegistrar 的模块的gRPC服务
+func (m *Manager) RegisterGRPC(s *grpc.Server) {
+	if m == nil {
+		return
+	}
+	for _, mod := range m.modules {
+		if r, ok := mod.(GRPCRegistrar); ok {
+			r.RegisterGRPC(s)
+		}
+	}
+}
@@ -0,0 +1,99 @@
+// Package module 功能模块：业务功能在 init 中调用 Register 注册模块工厂，
+// 由 Manager 统一构建组件、迁移模型、挂载路由并随应用启停，新增功能无需修改 wire、Container 和路由文件
+package module
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/mjcode-max/TurboGin/pkg/route"
+)
+
+// Module 功能模块，未用到的方法可通过嵌入 Base 省略
+type Module interface {
+	// Name 模块名称（小写），用于 MODULES.DISABLED 和日志
+	Name() string
+	// Providers 构建模块组件，按注册顺序执行，早于 RegisterRoutes
+	Providers() []Provider
+	// RegisterRoutes 在 MODULES.ROUTE_PREFIX（默认 /v1）分组下注册路由，文档分组默认为模块名称；
+	// turbo routes 生成路由表时不执行 Providers，此时模块组件为nil，注册路由时不应调用它们
+	RegisterRoutes(r *route.Router)
+	// Migrations 需要 AutoMigrate 的模型，MODULES.AUTO_MIGRATE 开启时在启动前执行
+	Migrations() []interface{}
+	// OnStart、OnStop 随应用生命周期调用，OnStop 按注册的逆序执行
+	OnStart(ctx context.Context) error
+	OnStop(ctx context.Context) error
+}
+
+// Provider 构建模块组件，需要共享给其他模块的组件通过 Provide 放入 Deps
+type Provider func(d *Deps) error
+
+// Requirer 可选接口，声明模块依赖的组件（DATABASE、REDIS、JWT、JOB、SCHEDULER、REALTIME），
+// 依赖的组件未启用时启动失败
+type Requirer interface {
+	Requires() []string
+}
+
+// Base 提供 Module 中除 Name 外方法的空实现
+type Base struct{}
+
+func (Base) Providers() []Provider         { return nil }
+func (Base) RegisterRoutes(*route.Router)  {}
+func (Base) Migrations() []interface{}     { return nil }
+func (Base) OnStart(context.Context) error { return nil }
+func (Base) OnStop(context.Context) error  { return nil }
+
+// Factory 创建模块实例；每次构建应用都会创建新实例，模块状态不在应用之间共享
+type Factory func() Module
+
+type registration struct {
+	name    string
+	factory Factory
+}
+
+var (
+	registryMu sync.Mutex
+	registry   []registration
+)
+
+// Register 注册模块工厂，通常在模块所在包的 init 中调用；名称重复时panic
+func Register(f Factory) {
+	name := f().Name()
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	for _, existing := range registry {
+		if existing.name == name {
+			panic(fmt.Sprintf("module: %q registered twice", name))
+		}
+	}
+	registry = append(registry, registration{name: name, factory: f})
+}
+
+// Registered 按注册顺序为全部已注册的模块（含被配置禁用的）创建新实例
+func Registered() []Module {
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	modules := make([]Module, len(registry))
+	for i, r := range registry {
+		modules[i] = r.factory()
+	}
+	return modules
+}
+
+// Requiring 返回依赖指定组件的模块名称，例如测试中关闭数据库时一并禁用这些模块
+func Requiring(component string) []string {
+	var names []string
+	for _, m := range Registered() {
+		if r, ok := m.(Requirer); ok {
+			for _, req := range r.Requires() {
+				if req == component {
+					names = append(names, m.Name())
+					break
+				}
+			}
+		}
+	}
+	return names
+}
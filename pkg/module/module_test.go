@@ -0,0 +1,62 @@
+package module
+
+import (
+	"testing"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// stateful 在 Providers 中保存构建它的 Deps
+type stateful struct {
+	Base
+	deps *Deps
+}
+
+func (m *stateful) Name() string { return "stateful" }
+
+func (m *stateful) Providers() []Provider {
+	return []Provider{func(d *Deps) error {
+		m.deps = d
+		return nil
+	}}
+}
+
+func init() {
+	Register(func() Module { return &stateful{} })
+}
+
+func newManager(t *testing.T) *Manager {
+	t.Helper()
+	cfg := &config.Config{}
+	lc, cleanup := app.NewLifecycle(cfg, logger.NewNop())
+	t.Cleanup(cleanup)
+	m, err := New(cfg, lc, logger.NewNop(), nil, nil, nil, nil, nil, nil, nil, nil)
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return m
+}
+
+func TestManagerOwnsModuleInstances(t *testing.T) {
+	first := newManager(t)
+	second := newManager(t)
+
+	a, b := first.modules[0].(*stateful), second.modules[0].(*stateful)
+	if a == b {
+		t.Fatal("managers share a module instance")
+	}
+	if a.deps != first.Deps() || b.deps != second.Deps() {
+		t.Fatal("module state was overwritten by another manager")
+	}
+}
+
+func TestRegisterDuplicatePanics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Fatal("duplicate Register did not panic")
+		}
+	}()
+	Register(func() Module { return &stateful{} })
+}
@@ -0,0 +1,146 @@
+package realtime
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// 关闭码（RFC 6455）
+const (
+	closeNormal          = 1000
+	closeGoingAway       = 1001
+	closePolicyViolation = 1008
+)
+
+// Client 单个WebSocket或SSE连接
+type Client struct {
+	ID     string
+	UserID string
+	Claims jwt.MapClaims
+
+	hub    *Hub
+	topics map[string]struct{} // 受hub.mu保护
+	send   chan Message
+
+	done        chan struct{}
+	closeOnce   sync.Once
+	closeCode   int
+	closeReason string
+}
+
+func newClient(h *Hub, claims jwt.MapClaims) *Client {
+	id := make([]byte, 8)
+	_, _ = rand.Read(id)
+
+	c := &Client{
+		ID:     hex.EncodeToString(id),
+		Claims: claims,
+		hub:    h,
+		topics: make(map[string]struct{}),
+		send:   make(chan Message, h.cfg.SendBuffer),
+		done:   make(chan struct{}),
+	}
+	if uid, ok := claims["userID"]; ok {
+		c.UserID = fmt.Sprint(uid)
+	}
+	return c
+}
+
+// Send 向该连接推送消息，发送队列已满时返回false
+func (c *Client) Send(msg Message) bool {
+	return c.enqueue(msg)
+}
+
+// Topics 当前订阅的主题
+func (c *Client) Topics() []string {
+	c.hub.mu.RLock()
+	defer c.hub.mu.RUnlock()
+
+	topics := make([]string, 0, len(c.topics))
+	for t := range c.topics {
+		topics = append(topics, t)
+	}
+	return topics
+}
+
+// Close 断开连接
+func (c *Client) Close(code int, reason string) {
+	c.closeOnce.Do(func() {
+		c.closeCode, c.closeReason = code, reason
+		close(c.done)
+	})
+}
+
+// Done 连接断开时关闭
+func (c *Client) Done() <-chan struct{} {
+	return c.done
+}
+
+// enqueue 非阻塞写入发送队列
+func (c *Client) enqueue(msg Message) bool {
+	select {
+	case <-c.done:
+		return true
+	default:
+	}
+
+	select {
+	case c.send <- msg:
+		return true
+	default:
+		return false
+	}
+}
+
+// authenticate 握手阶段校验JWT，浏览器无法为WebSocket设置请求头时可使用?token=
+func (h *Hub) authenticate(c *gin.Context) (jwt.MapClaims, bool) {
+	if h.auth == nil {
+		return jwt.MapClaims{}, true
+	}
+
+	token := c.GetHeader("Authorization")
+	if len(token) > 7 && strings.ToUpper(token[0:6]) == "BEARER" {
+		token = token[7:]
+	}
+	if token == "" {
+		token = c.Query("token")
+	}
+	if token == "" {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Authorization required", "code": http.StatusUnauthorized})
+		return nil, false
+	}
+
+	claims, err := h.auth.ParseToken(token)
+	if err != nil {
+		c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{"error": "Invalid or expired token", "code": http.StatusUnauthorized})
+		return nil, false
+	}
+	return claims, true
+}
+
+// accept 认证并登记连接，失败时已写入响应
+func (h *Hub) accept(c *gin.Context) (*Client, bool) {
+	claims, ok := h.authenticate(c)
+	if !ok {
+		return nil, false
+	}
+
+	client := newClient(h, claims)
+	if err := h.register(client); err != nil {
+		c.AbortWithStatusJSON(http.StatusServiceUnavailable, gin.H{"error": err.Error(), "code": http.StatusServiceUnavailable})
+		return nil, false
+	}
+	return client, true
+}
+
+// disabled Hub为nil时注册的占位处理器
+func disabled(c *gin.Context) {
+	c.AbortWithStatusJSON(http.StatusNotFound, gin.H{"error": ErrDisabled.Error(), "code": http.StatusNotFound})
+}
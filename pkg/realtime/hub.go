@@ -0,0 +1,378 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// 慢消费者处理策略
+const (
+	SlowConsumerDisconnect = "disconnect"
+	SlowConsumerDrop       = "drop"
+)
+
+var (
+	// ErrDisabled 未开启REALTIME时调用推送接口
+	ErrDisabled = errors.New("realtime: disabled")
+	// ErrClosed Hub已关闭
+	ErrClosed = errors.New("realtime: hub closed")
+	// ErrForbidden 无权订阅该主题，例如订阅其他用户的专属主题
+	ErrForbidden = errors.New("realtime: subscription forbidden")
+)
+
+// userTopicPrefix 用户专属主题前缀，只有对应用户本人可以订阅
+const userTopicPrefix = "user:"
+
+// Message 推送给客户端的消息
+type Message struct {
+	Topic string          `json:"topic,omitempty"`
+	Event string          `json:"event,omitempty"`
+	Data  json.RawMessage `json:"data,omitempty"`
+}
+
+// MessageHandler 处理客户端上行消息（仅WebSocket）
+type MessageHandler func(ctx context.Context, c *Client, msg Message)
+
+// SubscribeHandler 订阅前的额外权限校验，返回错误时拒绝订阅；用户专属主题的校验总是先于它执行
+type SubscribeHandler func(c *Client, topic string) error
+
+// Hub 管理所有长连接及其订阅的主题
+type Hub struct {
+	cfg  *config.RealtimeConfig
+	auth *middleware.Auth
+	rdb  *redis.Client
+	log  *logger.Logger
+
+	mu      sync.RWMutex
+	clients map[*Client]struct{}
+	topics  map[string]map[*Client]struct{}
+
+	onMessage   MessageHandler
+	onSubscribe SubscribeHandler
+
+	count   atomic.Int64
+	closing atomic.Bool
+	wg      sync.WaitGroup
+
+	pubsub *goredis.PubSub
+	subWG  sync.WaitGroup
+}
+
+// New 构造函数，未开启时返回nil
+func New(cfg *config.Config, lc *app.Lifecycle, auth *middleware.Auth, client *redis.Client, log *logger.Logger) (*Hub, error) {
+	if !cfg.Realtime.Enabled {
+		return nil, nil
+	}
+	if cfg.Realtime.Fanout && client == nil {
+		return nil, fmt.Errorf("realtime: fanout requires REDIS.ENABLED")
+	}
+
+	h := &Hub{
+		cfg:     &cfg.Realtime,
+		auth:    auth,
+		rdb:     client,
+		log:     log.WithFields(logger.String("component", "realtime")),
+		clients: make(map[*Client]struct{}),
+		topics:  make(map[string]map[*Client]struct{}),
+	}
+
+	// 开始关闭时立即断开所有客户端，HTTP服务无需等待长连接超时
+	go func() {
+		<-lc.Draining()
+		h.closeClients()
+	}()
+
+	lc.Append(app.Hook{
+		Name:    "realtime",
+		OnStart: h.start,
+		OnStop:  h.stop,
+	})
+	return h, nil
+}
+
+// OnMessage 设置客户端上行消息处理函数
+func (h *Hub) OnMessage(fn MessageHandler) {
+	if h == nil {
+		return
+	}
+	h.onMessage = fn
+}
+
+// OnSubscribe 设置额外的订阅权限校验函数，不能放宽内置的用户专属主题校验
+func (h *Hub) OnSubscribe(fn SubscribeHandler) {
+	if h == nil {
+		return
+	}
+	h.onSubscribe = fn
+}
+
+// Publish 向订阅了topic的客户端推送消息，开启FANOUT时经Redis送达所有副本
+func (h *Hub) Publish(ctx context.Context, topic, event string, data interface{}) error {
+	if h == nil {
+		return ErrDisabled
+	}
+	if h.closing.Load() {
+		return ErrClosed
+	}
+
+	raw, err := json.Marshal(data)
+	if err != nil {
+		return fmt.Errorf("realtime: encode data: %w", err)
+	}
+	msg := Message{Topic: topic, Event: event, Data: raw}
+
+	if h.cfg.Fanout {
+		payload, err := json.Marshal(msg)
+		if err != nil {
+			return err
+		}
+		if err := h.rdb.GetClient().Publish(ctx, h.cfg.Channel, payload).Err(); err != nil {
+			return fmt.Errorf("realtime: publish: %w", err)
+		}
+		return nil
+	}
+
+	h.deliver(msg)
+	return nil
+}
+
+// Broadcast 向所有在线客户端推送消息
+func (h *Hub) Broadcast(ctx context.Context, event string, data interface{}) error {
+	return h.Publish(ctx, "", event, data)
+}
+
+// SendToUser 向指定用户的所有连接推送消息
+func (h *Hub) SendToUser(ctx context.Context, userID, event string, data interface{}) error {
+	return h.Publish(ctx, UserTopic(userID), event, data)
+}
+
+// UserTopic 已认证客户端自动订阅的用户专属主题
+func UserTopic(userID string) string {
+	return userTopicPrefix + userID
+}
+
+// Clients 当前实例的在线连接数
+func (h *Hub) Clients() int {
+	if h == nil {
+		return 0
+	}
+	return int(h.count.Load())
+}
+
+// Subscribe 为客户端订阅主题
+func (h *Hub) Subscribe(c *Client, topic string) error {
+	if topic == "" {
+		return fmt.Errorf("realtime: empty topic")
+	}
+	// 用户专属主题只允许本人订阅，匿名连接不能订阅任何用户主题
+	if strings.HasPrefix(topic, userTopicPrefix) && (c.UserID == "" || topic != UserTopic(c.UserID)) {
+		return fmt.Errorf("%w: %s", ErrForbidden, topic)
+	}
+	if h.onSubscribe != nil {
+		if err := h.onSubscribe(c, topic); err != nil {
+			return err
+		}
+	}
+	h.subscribe(c, topic)
+	return nil
+}
+
+// Unsubscribe 取消订阅
+func (h *Hub) Unsubscribe(c *Client, topic string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	delete(c.topics, topic)
+	if subs, ok := h.topics[topic]; ok {
+		delete(subs, c)
+		if len(subs) == 0 {
+			delete(h.topics, topic)
+		}
+	}
+}
+
+func (h *Hub) subscribe(c *Client, topic string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if _, ok := h.clients[c]; !ok {
+		return
+	}
+	subs, ok := h.topics[topic]
+	if !ok {
+		subs = make(map[*Client]struct{})
+		h.topics[topic] = subs
+	}
+	subs[c] = struct{}{}
+	c.topics[topic] = struct{}{}
+}
+
+// register 登记新连接，超出连接上限或正在关闭时拒绝
+func (h *Hub) register(c *Client) error {
+	if h.closing.Load() {
+		return ErrClosed
+	}
+	n := h.count.Add(1)
+	if max := int64(h.cfg.MaxConnections); max > 0 && n > max {
+		h.count.Add(-1)
+		return fmt.Errorf("realtime: connection limit %d reached", max)
+	}
+
+	h.mu.Lock()
+	h.clients[c] = struct{}{}
+	h.mu.Unlock()
+	h.wg.Add(1)
+
+	if c.UserID != "" {
+		h.subscribe(c, UserTopic(c.UserID))
+	}
+	// 与closeClients并发时确保不会遗漏
+	if h.closing.Load() {
+		c.Close(closeGoingAway, "server shutting down")
+	}
+	return nil
+}
+
+// unregister 移除连接及其所有订阅
+func (h *Hub) unregister(c *Client) {
+	h.mu.Lock()
+	if _, ok := h.clients[c]; !ok {
+		h.mu.Unlock()
+		return
+	}
+	delete(h.clients, c)
+	for topic := range c.topics {
+		if subs, ok := h.topics[topic]; ok {
+			delete(subs, c)
+			if len(subs) == 0 {
+				delete(h.topics, topic)
+			}
+		}
+	}
+	h.mu.Unlock()
+
+	h.count.Add(-1)
+	h.wg.Done()
+}
+
+// deliver 投递到本实例的订阅者，topic为空时投递给所有连接
+func (h *Hub) deliver(msg Message) {
+	h.mu.RLock()
+	var targets []*Client
+	if msg.Topic == "" {
+		targets = make([]*Client, 0, len(h.clients))
+		for c := range h.clients {
+			targets = append(targets, c)
+		}
+	} else {
+		targets = make([]*Client, 0, len(h.topics[msg.Topic]))
+		for c := range h.topics[msg.Topic] {
+			targets = append(targets, c)
+		}
+	}
+	h.mu.RUnlock()
+
+	for _, c := range targets {
+		if !c.enqueue(msg) {
+			h.slowConsumer(c)
+		}
+	}
+}
+
+// slowConsumer 发送队列已满时按策略处理
+func (h *Hub) slowConsumer(c *Client) {
+	if h.cfg.SlowConsumer == SlowConsumerDrop {
+		h.log.Debug("Message dropped for slow consumer", logger.String("client", c.ID))
+		return
+	}
+	h.log.Warn("Disconnecting slow consumer", logger.String("client", c.ID), logger.String("user", c.UserID))
+	c.Close(closePolicyViolation, "slow consumer")
+}
+
+// start 开启FANOUT时订阅Redis频道
+func (h *Hub) start(ctx context.Context) error {
+	if !h.cfg.Fanout {
+		return nil
+	}
+
+	h.pubsub = h.rdb.GetClient().Subscribe(ctx, h.cfg.Channel)
+	if _, err := h.pubsub.Receive(ctx); err != nil {
+		_ = h.pubsub.Close()
+		return fmt.Errorf("realtime: subscribe %s: %w", h.cfg.Channel, err)
+	}
+
+	h.subWG.Add(1)
+	go h.receive()
+	return nil
+}
+
+// receive 将Redis频道中的消息投递到本实例的客户端
+func (h *Hub) receive() {
+	defer h.subWG.Done()
+
+	for m := range h.pubsub.Channel() {
+		var msg Message
+		if err := json.Unmarshal([]byte(m.Payload), &msg); err != nil {
+			h.log.Warn("Invalid fanout message", logger.Error(err))
+			continue
+		}
+		h.deliver(msg)
+	}
+}
+
+// closeClients 拒绝新连接并通知所有客户端断开
+func (h *Hub) closeClients() {
+	if !h.closing.CompareAndSwap(false, true) {
+		return
+	}
+
+	h.mu.RLock()
+	clients := make([]*Client, 0, len(h.clients))
+	for c := range h.clients {
+		clients = append(clients, c)
+	}
+	h.mu.RUnlock()
+
+	for _, c := range clients {
+		c.Close(closeGoingAway, "server shutting down")
+	}
+	if len(clients) > 0 {
+		h.log.Info("Realtime clients disconnected", logger.Int("clients", len(clients)))
+	}
+}
+
+// stop 等待所有连接退出并关闭Redis订阅
+func (h *Hub) stop(ctx context.Context) error {
+	h.closeClients()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = fmt.Errorf("realtime: stop: %w", ctx.Err())
+	}
+
+	if h.pubsub != nil {
+		_ = h.pubsub.Close()
+		h.subWG.Wait()
+	}
+	return err
+}
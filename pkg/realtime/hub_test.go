@@ -0,0 +1,127 @@
+package realtime
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+func newHub(t *testing.T) *Hub {
+	t.Helper()
+	cfg := &config.Config{Realtime: config.RealtimeConfig{Enabled: true, SendBuffer: 8}}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	h, err := New(cfg, lc, nil, nil, logger.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return h
+}
+
+func connect(t *testing.T, h *Hub, claims jwt.MapClaims) *Client {
+	t.Helper()
+	c := newClient(h, claims)
+	if err := h.register(c); err != nil {
+		t.Fatalf("register: %v", err)
+	}
+	t.Cleanup(func() { h.unregister(c) })
+	return c
+}
+
+func receive(c *Client) (Message, bool) {
+	select {
+	case msg := <-c.send:
+		return msg, true
+	case <-time.After(50 * time.Millisecond):
+		return Message{}, false
+	}
+}
+
+func TestUserTopicOnlyForOwner(t *testing.T) {
+	h := newHub(t)
+	alice := connect(t, h, jwt.MapClaims{"userID": float64(1)})
+	mallory := connect(t, h, jwt.MapClaims{"userID": float64(2)})
+	anonymous := connect(t, h, nil)
+
+	if err := h.Subscribe(alice, UserTopic("1")); err != nil {
+		t.Fatalf("owner subscribe: %v", err)
+	}
+	for _, c := range []*Client{mallory, anonymous} {
+		for _, topic := range []string{UserTopic("1"), "user:", "user:1:extra"} {
+			if err := h.Subscribe(c, topic); !errors.Is(err, ErrForbidden) {
+				t.Fatalf("client %q subscribe %q = %v, want ErrForbidden", c.UserID, topic, err)
+			}
+		}
+	}
+
+	if err := h.SendToUser(context.Background(), "1", "notice", "secret"); err != nil {
+		t.Fatalf("SendToUser: %v", err)
+	}
+	if _, ok := receive(alice); !ok {
+		t.Fatal("owner did not receive the message")
+	}
+	if msg, ok := receive(mallory); ok {
+		t.Fatalf("other user received %+v", msg)
+	}
+	if msg, ok := receive(anonymous); ok {
+		t.Fatalf("anonymous client received %+v", msg)
+	}
+}
+
+func TestOnSubscribeCannotBypassUserTopic(t *testing.T) {
+	h := newHub(t)
+	var checked []string
+	h.OnSubscribe(func(_ *Client, topic string) error {
+		checked = append(checked, topic)
+		if topic == "admin" {
+			return ErrForbidden
+		}
+		return nil
+	})
+	c := connect(t, h, jwt.MapClaims{"userID": "2"})
+
+	if err := h.Subscribe(c, UserTopic("1")); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("subscribe other user's topic = %v, want ErrForbidden", err)
+	}
+	if err := h.Subscribe(c, "admin"); !errors.Is(err, ErrForbidden) {
+		t.Fatalf("subscribe admin = %v, want ErrForbidden from OnSubscribe", err)
+	}
+	if err := h.Subscribe(c, "news"); err != nil {
+		t.Fatalf("subscribe news: %v", err)
+	}
+	if len(checked) != 2 || checked[0] != "admin" || checked[1] != "news" {
+		t.Fatalf("OnSubscribe saw %v, want [admin news]", checked)
+	}
+}
+
+func TestPublishToTopic(t *testing.T) {
+	h := newHub(t)
+	sub := connect(t, h, nil)
+	other := connect(t, h, nil)
+	if err := h.Subscribe(sub, "news"); err != nil {
+		t.Fatalf("Subscribe: %v", err)
+	}
+
+	if err := h.Publish(context.Background(), "news", "created", map[string]int{"id": 1}); err != nil {
+		t.Fatalf("Publish: %v", err)
+	}
+	msg, ok := receive(sub)
+	if !ok || msg.Topic != "news" || msg.Event != "created" || string(msg.Data) != `{"id":1}` {
+		t.Fatalf("received %+v, %v", msg, ok)
+	}
+	if _, ok := receive(other); ok {
+		t.Fatal("unsubscribed client received the message")
+	}
+
+	h.Unsubscribe(sub, "news")
+	_ = h.Publish(context.Background(), "news", "created", nil)
+	if _, ok := receive(sub); ok {
+		t.Fatal("received after Unsubscribe")
+	}
+}
@@ -0,0 +1,90 @@
+package realtime
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// SSE 生成Server-Sent Events处理器，通过?topic=a&topic=b指定订阅的主题
+func (h *Hub) SSE() gin.HandlerFunc {
+	if h == nil {
+		return disabled
+	}
+
+	return func(c *gin.Context) {
+		client, ok := h.accept(c)
+		if !ok {
+			return
+		}
+		defer h.unregister(client)
+
+		for _, topic := range c.QueryArray("topic") {
+			if err := h.Subscribe(client, topic); err != nil {
+				c.AbortWithStatusJSON(http.StatusForbidden, gin.H{"error": err.Error(), "code": http.StatusForbidden})
+				return
+			}
+		}
+
+		w := c.Writer
+		rc := http.NewResponseController(w)
+		header := w.Header()
+		header.Set("Content-Type", "text/event-stream")
+		header.Set("Cache-Control", "no-cache")
+		header.Set("Connection", "keep-alive")
+		header.Set("X-Accel-Buffering", "no") // 关闭Nginx缓冲
+		w.WriteHeader(http.StatusOK)
+		_ = rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+		fmt.Fprintf(w, "retry: %d\n\n", 3000)
+		w.Flush()
+
+		// 长连接不受SERVER.WRITE_TIMEOUT限制，每次写入前单独设置写超时
+		ticker := time.NewTicker(h.cfg.PingInterval)
+		defer ticker.Stop()
+
+		h.log.Debug("SSE connected", logger.String("client", client.ID), logger.String("user", client.UserID))
+		for {
+			select {
+			case <-c.Request.Context().Done():
+				return
+			case <-client.done:
+				if client.closeCode == closeGoingAway {
+					// 通知客户端重连到其他实例
+					_ = rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+					fmt.Fprint(w, "event: close\ndata: {}\n\n")
+					w.Flush()
+				}
+				return
+			case msg := <-client.send:
+				_ = rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+				if err := writeEvent(w, msg); err != nil {
+					return
+				}
+			case <-ticker.C:
+				_ = rc.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+				if _, err := fmt.Fprint(w, ": ping\n\n"); err != nil {
+					return
+				}
+			}
+			w.Flush()
+		}
+	}
+}
+
+// writeEvent 按SSE格式写出消息，data为完整的Message JSON
+func writeEvent(w gin.ResponseWriter, msg Message) error {
+	data, err := json.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	event := msg.Event
+	if event == "" {
+		event = "message"
+	}
+	_, err = fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data)
+	return err
+}
@@ -0,0 +1,178 @@
+package realtime
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gorilla/websocket"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// 客户端上行控制消息
+const (
+	actionSubscribe   = "subscribe"
+	actionUnsubscribe = "unsubscribe"
+)
+
+// inbound 客户端上行消息，action为空时交给OnMessage处理
+type inbound struct {
+	Action string          `json:"action"`
+	Topic  string          `json:"topic"`
+	Event  string          `json:"event"`
+	Data   json.RawMessage `json:"data"`
+}
+
+// WebSocket 生成WebSocket升级处理器，可通过?topic=a&topic=b在握手时订阅
+func (h *Hub) WebSocket() gin.HandlerFunc {
+	if h == nil {
+		return disabled
+	}
+
+	upgrader := websocket.Upgrader{
+		ReadBufferSize:  4096,
+		WriteBufferSize: 4096,
+		CheckOrigin:     h.checkOrigin,
+	}
+
+	return func(c *gin.Context) {
+		client, ok := h.accept(c)
+		if !ok {
+			return
+		}
+
+		conn, err := upgrader.Upgrade(c.Writer, c.Request, nil)
+		if err != nil {
+			// Upgrade已写入错误响应
+			h.unregister(client)
+			return
+		}
+
+		for _, topic := range c.QueryArray("topic") {
+			if err := h.Subscribe(client, topic); err != nil {
+				h.log.Debug("Subscribe rejected", logger.String("client", client.ID), logger.String("topic", topic), logger.Error(err))
+			}
+		}
+
+		h.log.Debug("WebSocket connected", logger.String("client", client.ID), logger.String("user", client.UserID))
+		go h.writePump(client, conn)
+		h.readPump(c.Request.Context(), client, conn)
+	}
+}
+
+// readPump 读取上行消息并维护读超时，返回时连接已断开
+func (h *Hub) readPump(ctx context.Context, c *Client, conn *websocket.Conn) {
+	defer c.Close(closeNormal, "")
+
+	conn.SetReadLimit(h.cfg.MaxMessageSize)
+	_ = conn.SetReadDeadline(time.Now().Add(h.cfg.PongTimeout))
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(h.cfg.PongTimeout))
+	})
+
+	// 请求上下文在Hijack后不会随连接关闭取消，由done控制
+	ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancel()
+	go func() {
+		<-c.done
+		cancel()
+	}()
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway, websocket.CloseNoStatusReceived) &&
+				!errors.Is(err, websocket.ErrReadLimit) {
+				h.log.Debug("WebSocket read error", logger.String("client", c.ID), logger.Error(err))
+			}
+			if errors.Is(err, websocket.ErrReadLimit) {
+				c.Close(websocket.CloseMessageTooBig, "message too big")
+			}
+			return
+		}
+
+		var in inbound
+		if err := json.Unmarshal(data, &in); err != nil {
+			c.enqueue(Message{Event: "error", Data: errorData("invalid message")})
+			continue
+		}
+
+		switch in.Action {
+		case actionSubscribe:
+			if err := h.Subscribe(c, in.Topic); err != nil {
+				c.enqueue(Message{Topic: in.Topic, Event: "error", Data: errorData(err.Error())})
+			}
+		case actionUnsubscribe:
+			h.Unsubscribe(c, in.Topic)
+		default:
+			if h.onMessage != nil {
+				h.onMessage(ctx, c, Message{Topic: in.Topic, Event: in.Event, Data: in.Data})
+			}
+		}
+	}
+}
+
+// writePump 串行写出消息与心跳，连接关闭时发送关闭帧并注销
+func (h *Hub) writePump(c *Client, conn *websocket.Conn) {
+	ticker := time.NewTicker(h.cfg.PingInterval)
+	defer func() {
+		ticker.Stop()
+		_ = conn.Close()
+		h.unregister(c)
+	}()
+
+	for {
+		select {
+		case msg := <-c.send:
+			_ = conn.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+			if err := conn.WriteJSON(msg); err != nil {
+				c.Close(closeNormal, "")
+				return
+			}
+		case <-ticker.C:
+			_ = conn.SetWriteDeadline(time.Now().Add(h.cfg.WriteTimeout))
+			if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+				c.Close(closeNormal, "")
+				return
+			}
+		case <-c.done:
+			code := c.closeCode
+			if code == 0 {
+				code = closeNormal
+			}
+			_ = conn.WriteControl(websocket.CloseMessage,
+				websocket.FormatCloseMessage(code, c.closeReason),
+				time.Now().Add(h.cfg.WriteTimeout))
+			return
+		}
+	}
+}
+
+// checkOrigin 校验跨域握手，未配置时仅允许同源
+func (h *Hub) checkOrigin(r *http.Request) bool {
+	origin := r.Header.Get("Origin")
+	if origin == "" {
+		return true
+	}
+
+	if len(h.cfg.AllowedOrigins) == 0 {
+		u, err := url.Parse(origin)
+		return err == nil && strings.EqualFold(u.Host, r.Host)
+	}
+	for _, allowed := range h.cfg.AllowedOrigins {
+		if allowed == "*" || strings.EqualFold(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func errorData(message string) json.RawMessage {
+	data, _ := json.Marshal(map[string]string{"error": message})
+	return data
+}
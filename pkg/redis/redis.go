@@ -2,54 +2,169 @@ package redis
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"fmt"
+	"os"
+	"strings"
 	"time"
 
 	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
 	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/secret"
 	"github.com/redis/go-redis/v9"
 )
 
+// 部署模式
+const (
+	ModeStandalone = "standalone"
+	ModeSentinel   = "sentinel"
+	ModeCluster    = "cluster"
+)
+
 type Client struct {
-	cli *redis.Client
+	cli redis.UniversalClient
 	cfg *config.RedisConfig
 	log *logger.Logger
 }
 
-// New 创建Redis客户端
-func New(cfg *config.Config, log *logger.Logger) (*Client, error) {
+// New 创建Redis客户端（根据MODE自动选择单机/哨兵/集群）
+func New(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger, secrets *secret.Watcher) (*Client, error) {
 	if !cfg.Redis.Enabled {
 		return nil, nil
 	}
-	cli := redis.NewClient(&redis.Options{
-		Addr:         cfg.Redis.Addr,
-		Password:     cfg.Redis.Password,
-		DB:           cfg.Redis.DB,
-		DialTimeout:  5 * time.Second, // 连接超时
-		ReadTimeout:  3 * time.Second, // 读超时
-		WriteTimeout: 3 * time.Second, // 写超时
-	})
+
+	cli, err := newUniversalClient(&cfg.Redis, secrets)
+	if err != nil {
+		return nil, err
+	}
 
 	// 健康检查
 	ctx, cancel := context.WithTimeout(context.Background(), 3*time.Second)
 	defer cancel()
 	if _, err := cli.Ping(ctx).Result(); err != nil {
+		_ = cli.Close()
 		return nil, fmt.Errorf("redis connection failed: %w", err)
 	}
 
 	log.Info("Redis connected successfully",
-		logger.String("addr", cfg.Redis.Addr),
-		logger.Int("db", cfg.Redis.DB))
+		logger.String("mode", cfg.Redis.Mode),
+		logger.Strings("addrs", addrs(&cfg.Redis)),
+		logger.Int("db", cfg.Redis.DB),
+		logger.Bool("tls", cfg.Redis.TLS.Enabled))
 
-	return &Client{
+	c := &Client{
 		cli: cli,
 		cfg: &cfg.Redis,
 		log: log,
-	}, nil
+	}
+
+	// 连接已建立，应用关闭时释放
+	lc.Append(app.Hook{
+		Name: "redis",
+		OnStop: func(context.Context) error {
+			return c.Close()
+		},
+	})
+
+	return c, nil
+}
+
+// newUniversalClient 按部署模式构建客户端
+func newUniversalClient(cfg *config.RedisConfig, secrets *secret.Watcher) (redis.UniversalClient, error) {
+	tlsConfig, err := buildTLSConfig(&cfg.TLS)
+	if err != nil {
+		return nil, err
+	}
+
+	opts := &redis.UniversalOptions{
+		Addrs:            addrs(cfg),
+		MasterName:       cfg.MasterName,
+		Username:         cfg.Username,
+		Password:         cfg.Password,
+		SentinelUsername: cfg.SentinelUsername,
+		SentinelPassword: cfg.SentinelPassword,
+		DB:               cfg.DB,
+		PoolSize:         cfg.PoolSize,
+		MinIdleConns:     cfg.MinIdleConns,
+		DialTimeout:      cfg.DialTimeout,
+		ReadTimeout:      cfg.ReadTimeout,
+		WriteTimeout:     cfg.WriteTimeout,
+		PoolTimeout:      cfg.PoolTimeout,
+		ConnMaxIdleTime:  cfg.ConnMaxIdleTime,
+		TLSConfig:        tlsConfig,
+	}
+	if secrets != nil {
+		// 新建连接时读取当前密码，REDIS.PASSWORD 轮换后无需重启
+		opts.CredentialsProvider = func() (string, string) {
+			return cfg.Username, secrets.Get("REDIS.PASSWORD", cfg.Password)
+		}
+	}
+
+	switch strings.ToLower(cfg.Mode) {
+	case "", ModeStandalone:
+		return redis.NewClient(opts.Simple()), nil
+	case ModeSentinel:
+		return redis.NewFailoverClient(opts.Failover()), nil
+	case ModeCluster:
+		return redis.NewClusterClient(opts.Cluster()), nil
+	default:
+		return nil, fmt.Errorf("unsupported redis mode: %s", cfg.Mode)
+	}
+}
+
+// buildTLSConfig 根据配置构建TLS参数，未启用时返回nil
+func buildTLSConfig(cfg *config.RedisTLSConfig) (*tls.Config, error) {
+	if !cfg.Enabled {
+		return nil, nil
+	}
+
+	tlsConfig := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		ServerName:         cfg.ServerName,
+		InsecureSkipVerify: cfg.InsecureSkipVerify,
+	}
+
+	if cfg.CAFile != "" {
+		pem, err := os.ReadFile(cfg.CAFile)
+		if err != nil {
+			return nil, fmt.Errorf("read redis CA file: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no valid certificates in redis CA file: %s", cfg.CAFile)
+		}
+		tlsConfig.RootCAs = pool
+	}
+
+	if cfg.CertFile != "" && cfg.KeyFile != "" {
+		cert, err := tls.LoadX509KeyPair(cfg.CertFile, cfg.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("load redis client certificate: %w", err)
+		}
+		tlsConfig.Certificates = []tls.Certificate{cert}
+	}
+
+	return tlsConfig, nil
+}
+
+// addrs 合并ADDR与ADDRS配置
+func addrs(cfg *config.RedisConfig) []string {
+	if len(cfg.Addrs) > 0 {
+		return cfg.Addrs
+	}
+	if cfg.Addr != "" {
+		return []string{cfg.Addr}
+	}
+	return nil
 }
 
 // Close 安全关闭连接
 func (c *Client) Close() error {
+	if c == nil {
+		return nil
+	}
 	if err := c.cli.Close(); err != nil {
 		return fmt.Errorf("redis close error: %w", err)
 	}
@@ -57,13 +172,33 @@ func (c *Client) Close() error {
 	return nil
 }
 
-// GetClient 获取原生客户端（供特殊操作使用）
-func (c *Client) GetClient() *redis.Client {
+// Enabled 是否启用了Redis（New 在 REDIS.ENABLED=false 时返回nil）
+func (c *Client) Enabled() bool {
+	return c != nil
+}
+
+// GetClient 获取原生客户端（供特殊操作使用），单机/哨兵/集群模式下均可使用
+// 未启用Redis时返回nil，调用方应先通过 Enabled 判断并降级（如跳过缓存）
+func (c *Client) GetClient() redis.UniversalClient {
+	if c == nil {
+		return nil
+	}
 	return c.cli
 }
 
+// Mode 返回当前部署模式
+func (c *Client) Mode() string {
+	if c == nil {
+		return ""
+	}
+	return c.cfg.Mode
+}
+
 // HealthCheck 健康检查（供/health端点使用）
 func (c *Client) HealthCheck() bool {
+	if c == nil {
+		return false
+	}
 	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
 	defer cancel()
 	_, err := c.cli.Ping(ctx).Result()
@@ -0,0 +1,69 @@
+package route
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+)
+
+// Error 携带HTTP状态码的错误，处理函数可直接返回
+type Error struct {
+	Status  int
+	Message string
+	Err     error
+}
+
+// NewError 创建携带状态码的错误
+func NewError(status int, message string) *Error {
+	return &Error{Status: status, Message: message}
+}
+
+// WrapError 以指定状态码包装错误，响应中的消息为 message
+func WrapError(status int, message string, err error) *Error {
+	return &Error{Status: status, Message: message, Err: err}
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" && e.Err != nil {
+		return e.Err.Error()
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+var (
+	statusMu sync.RWMutex
+	statuses []errorStatus
+)
+
+type errorStatus struct {
+	target error
+	status int
+}
+
+// RegisterError 注册哨兵错误对应的状态码（按 errors.Is 匹配），通常在定义错误的包或控制器包的 init 中调用
+func RegisterError(target error, status int) {
+	statusMu.Lock()
+	defer statusMu.Unlock()
+	statuses = append(statuses, errorStatus{target: target, status: status})
+}
+
+// StatusOf 错误对应的状态码：*Error 使用其状态码，其次按注册顺序匹配哨兵错误，均不匹配时为 500
+func StatusOf(err error) int {
+	var e *Error
+	if errors.As(err, &e) && e.Status != 0 {
+		return e.Status
+	}
+
+	statusMu.RLock()
+	defer statusMu.RUnlock()
+	for _, s := range statuses {
+		if errors.Is(err, s.target) {
+			return s.status
+		}
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,241 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+	"github.com/gin-gonic/gin/binding"
+)
+
+// NoContent 作为响应类型时返回 204，不写响应体
+type NoContent struct{}
+
+// ErrorBody 错误响应体，与中间件（认证、限流等）返回的格式一致
+type ErrorBody struct {
+	Error string `json:"error"`
+	Code  int    `json:"code"`
+}
+
+// DataBody 成功响应体，与 ErrorBody 对应：Data 为处理函数的返回值，Code 为HTTP状态码
+type DataBody struct {
+	Data interface{} `json:"data"`
+	Code int         `json:"code"`
+}
+
+type ginContextKey struct{}
+
+// Handle 将类型化的处理函数适配为 gin.HandlerFunc：
+//
+//   - 按标签绑定请求：uri（路径参数）、form（查询参数）、header（请求头，使用规范写法如 If-Match）、json（请求体）；
+//     带 uri、form、header 标签的字段只从对应来源绑定，请求体中的同名字段不会覆盖
+//   - 按 binding 标签校验，绑定或校验失败返回 400
+//   - 错误按 StatusOf 映射状态码后以 ErrorBody 返回
+//   - 成功时 POST 返回 201，其余返回 200，响应类型为 NoContent 时返回 204
+//
+// 通过 route.GET 等注册时，请求、响应类型会记录到路由表用于生成 OpenAPI 文档
+func Handle[Req, Resp any](fn func(ctx context.Context, req Req) (Resp, error)) gin.HandlerFunc {
+	sources := bindSources(reflect.TypeOf((*Req)(nil)).Elem())
+	noContent := reflect.TypeOf((*Resp)(nil)).Elem() == reflect.TypeOf(NoContent{})
+
+	return func(c *gin.Context) {
+		var req Req
+		if err := bind(c, &req, sources); err != nil {
+			WriteError(c, NewError(http.StatusBadRequest, err.Error()))
+			return
+		}
+
+		resp, err := fn(context.WithValue(c.Request.Context(), ginContextKey{}, c), req)
+		if err != nil {
+			WriteError(c, err)
+			return
+		}
+
+		if noContent {
+			c.Status(http.StatusNoContent)
+			return
+		}
+		status := successStatus(c.Request.Method)
+		c.JSON(status, DataBody{Data: resp, Code: status})
+	}
+}
+
+// GinContext 取出 Handle 传给处理函数的 gin.Context，用于读写请求头等无法通过类型表达的场景
+func GinContext(ctx context.Context) *gin.Context {
+	c, _ := ctx.Value(ginContextKey{}).(*gin.Context)
+	return c
+}
+
+// WriteError 按 StatusOf 映射状态码并写入 ErrorBody；除 *Error 外的 5xx 错误不向客户端暴露详情，
+// 原始错误记录在 c.Errors 中由请求日志输出
+func WriteError(c *gin.Context, err error) {
+	_ = c.Error(err)
+	status := StatusOf(err)
+	message := err.Error()
+	var e *Error
+	if status >= http.StatusInternalServerError && !errors.As(err, &e) {
+		message = http.StatusText(status)
+	}
+	c.AbortWithStatusJSON(status, ErrorBody{Error: message, Code: status})
+}
+
+func successStatus(method string) int {
+	if method == http.MethodPost {
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+// GET 以类型化处理函数注册路由，并记录请求、响应类型
+func GET[Req, Resp any](r *Router, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	return handleTyped(r, http.MethodGet, path, fn)
+}
+
+func POST[Req, Resp any](r *Router, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	return handleTyped(r, http.MethodPost, path, fn)
+}
+
+func PUT[Req, Resp any](r *Router, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	return handleTyped(r, http.MethodPut, path, fn)
+}
+
+func PATCH[Req, Resp any](r *Router, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	return handleTyped(r, http.MethodPatch, path, fn)
+}
+
+func DELETE[Req, Resp any](r *Router, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	return handleTyped(r, http.MethodDelete, path, fn)
+}
+
+func handleTyped[Req, Resp any](r *Router, method, path string, fn func(ctx context.Context, req Req) (Resp, error)) *Route {
+	rt := r.Handle(method, path, Handle(fn))
+	rt.Handler = funcName(fn)
+
+	if reqType := reflect.TypeOf((*Req)(nil)).Elem(); reqType.Kind() != reflect.Struct || reqType.NumField() > 0 {
+		rt.RequestType = reqType
+	}
+	if respType := reflect.TypeOf((*Resp)(nil)).Elem(); respType == reflect.TypeOf(NoContent{}) {
+		rt.ResponseStatus = http.StatusNoContent
+	} else {
+		rt.ResponseStatus = successStatus(method)
+		rt.ResponseType = respType
+		rt.Envelope = true
+	}
+	return rt
+}
+
+// source 请求中的一个绑定来源
+type source struct {
+	tag    string
+	values func(c *gin.Context) map[string][]string
+}
+
+var allSources = []source{
+	{"uri", func(c *gin.Context) map[string][]string {
+		m := make(map[string][]string, len(c.Params))
+		for _, p := range c.Params {
+			m[p.Key] = []string{p.Value}
+		}
+		return m
+	}},
+	{"form", func(c *gin.Context) map[string][]string {
+		return c.Request.URL.Query()
+	}},
+	{"header", func(c *gin.Context) map[string][]string {
+		return c.Request.Header
+	}},
+}
+
+// bindPlan 请求类型用到的绑定来源
+type bindPlan struct {
+	sources []source
+	body    bool
+	// bound 带 uri、form、header 标签的字段，解码请求体后清零，只接受对应来源的值
+	bound [][]int
+}
+
+// bindSources 只使用请求类型中出现了对应标签的来源，避免未打标签的字段按字段名被其他来源误绑定
+func bindSources(t reflect.Type) bindPlan {
+	var plan bindPlan
+	if t.Kind() != reflect.Struct {
+		plan.body = true
+		return plan
+	}
+	tags := make(map[string]bool)
+	plan.bound = collectTags(t, nil, tags)
+	for _, s := range allSources {
+		if tags[s.tag] {
+			plan.sources = append(plan.sources, s)
+		}
+	}
+	plan.body = tags["json"]
+	return plan
+}
+
+func collectTags(t reflect.Type, index []int, tags map[string]bool) [][]int {
+	var bound [][]int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		fieldIndex := append(append([]int(nil), index...), i)
+		tagged := false
+		for _, tag := range []string{"uri", "form", "header"} {
+			if f.Tag.Get(tag) != "" {
+				tags[tag] = true
+				tagged = true
+			}
+		}
+		if tagged {
+			bound = append(bound, fieldIndex)
+			continue
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && ft.Kind() == reflect.Struct && f.Tag.Get("json") == "" {
+			bound = append(bound, collectTags(ft, fieldIndex, tags)...)
+			continue
+		}
+		if f.Tag.Get("json") != "-" {
+			tags["json"] = true
+		}
+	}
+	return bound
+}
+
+// bind 先解码请求体，再清空带 uri、form、header 标签的字段并从对应来源绑定，
+// 避免请求体中的 id 等字段覆盖路径参数或请求头（例如越权修改其他用户）
+func bind(c *gin.Context, req interface{}, plan bindPlan) error {
+	if plan.body && c.Request.Body != nil && c.Request.Body != http.NoBody {
+		if err := json.NewDecoder(c.Request.Body).Decode(req); err != nil && !errors.Is(err, io.EOF) {
+			return fmt.Errorf("invalid request body: %w", err)
+		}
+		resetBound(reflect.ValueOf(req).Elem(), plan.bound)
+	}
+	for _, s := range plan.sources {
+		if err := binding.MapFormWithTag(req, s.values(c), s.tag); err != nil {
+			return err
+		}
+	}
+	if binding.Validator == nil {
+		return nil
+	}
+	return binding.Validator.ValidateStruct(req)
+}
+
+func resetBound(v reflect.Value, bound [][]int) {
+	for _, index := range bound {
+		// 嵌入的结构体指针为nil时请求体未写入该字段，无需清零
+		if f, err := v.FieldByIndexErr(index); err == nil {
+			f.SetZero()
+		}
+	}
+}
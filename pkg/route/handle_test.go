@@ -0,0 +1,168 @@
+package route
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+)
+
+type updateRequest struct {
+	ID      uint    `uri:"id" binding:"required"`
+	Fields  string  `form:"fields"`
+	IfMatch string  `header:"If-Match"`
+	Name    *string `json:"name"`
+}
+
+type Paging struct {
+	Page int `form:"page"`
+}
+
+type listRequest struct {
+	Paging
+	Query string `json:"query"`
+}
+
+func serve(t *testing.T, method, path, target string, h gin.HandlerFunc, body string, header http.Header) *httptest.ResponseRecorder {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+	r := gin.New()
+	r.Handle(method, path, h)
+
+	req := httptest.NewRequest(method, target, strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	return w
+}
+
+func TestBindBodyDoesNotOverrideTaggedFields(t *testing.T) {
+	var got updateRequest
+	h := Handle(func(_ context.Context, req updateRequest) (NoContent, error) {
+		got = req
+		return NoContent{}, nil
+	})
+
+	w := serve(t, http.MethodPatch, "/users/:id", "/users/1?fields=name", h,
+		`{"id":2,"ID":2,"fields":"all","IfMatch":"\"7\"","name":"bob"}`,
+		http.Header{"If-Match": {`"3"`}})
+	if w.Code != http.StatusNoContent {
+		t.Fatalf("status = %d, body: %s", w.Code, w.Body.String())
+	}
+	if got.ID != 1 || got.Fields != "name" || got.IfMatch != `"3"` {
+		t.Fatalf("bound %+v, want ID 1, Fields name, IfMatch \"3\"", got)
+	}
+	if got.Name == nil || *got.Name != "bob" {
+		t.Fatalf("Name = %v, want bob", got.Name)
+	}
+}
+
+func TestBindIgnoresBodyForMissingHeader(t *testing.T) {
+	var got updateRequest
+	h := Handle(func(_ context.Context, req updateRequest) (NoContent, error) {
+		got = req
+		return NoContent{}, nil
+	})
+
+	serve(t, http.MethodPatch, "/users/:id", "/users/1", h, `{"IfMatch":"\"7\""}`, nil)
+	if got.IfMatch != "" {
+		t.Fatalf("IfMatch = %q, want empty when the header is absent", got.IfMatch)
+	}
+}
+
+func TestBindEmbeddedQuery(t *testing.T) {
+	var got listRequest
+	h := Handle(func(_ context.Context, req listRequest) (NoContent, error) {
+		got = req
+		return NoContent{}, nil
+	})
+
+	serve(t, http.MethodPost, "/search", "/search?page=2", h, `{"Page":9,"query":"a"}`, nil)
+	if got.Page != 2 || got.Query != "a" {
+		t.Fatalf("bound %+v, want Page 2, Query a", got)
+	}
+}
+
+func TestBindErrors(t *testing.T) {
+	h := Handle(func(_ context.Context, req updateRequest) (NoContent, error) {
+		return NoContent{}, nil
+	})
+
+	for name, tc := range map[string]struct{ target, body string }{
+		"non-numeric id": {"/users/abc", `{}`},
+		"invalid body":   {"/users/1", `{`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			w := serve(t, http.MethodPatch, "/users/:id", tc.target, h, tc.body, nil)
+			if w.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, want 400", w.Code)
+			}
+			var body ErrorBody
+			if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Code != http.StatusBadRequest || body.Error == "" {
+				t.Fatalf("body = %s", w.Body.String())
+			}
+		})
+	}
+}
+
+func TestHandleWritesEnvelope(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	h := Handle(func(_ context.Context, _ struct{}) (*user, error) {
+		return &user{Name: "alice"}, nil
+	})
+
+	for method, status := range map[string]int{http.MethodGet: http.StatusOK, http.MethodPost: http.StatusCreated} {
+		w := serve(t, method, "/users", "/users", h, "", nil)
+		if w.Code != status {
+			t.Fatalf("%s: status = %d, want %d", method, w.Code, status)
+		}
+		var body struct {
+			Data user `json:"data"`
+			Code int  `json:"code"`
+		}
+		if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil {
+			t.Fatalf("%s: decode %s: %v", method, w.Body.String(), err)
+		}
+		if body.Code != status || body.Data.Name != "alice" {
+			t.Fatalf("%s: body = %s", method, w.Body.String())
+		}
+	}
+}
+
+func TestHandleMapsErrors(t *testing.T) {
+	h := Handle(func(_ context.Context, _ struct{}) (NoContent, error) {
+		return NoContent{}, NewError(http.StatusPreconditionFailed, "version mismatch")
+	})
+
+	w := serve(t, http.MethodGet, "/", "/", h, "", nil)
+	if w.Code != http.StatusPreconditionFailed {
+		t.Fatalf("status = %d, want 412", w.Code)
+	}
+	var body ErrorBody
+	if err := json.Unmarshal(w.Body.Bytes(), &body); err != nil || body.Error != "version mismatch" {
+		t.Fatalf("body = %s", w.Body.String())
+	}
+}
+
+func TestOpenAPIEnvelope(t *testing.T) {
+	type user struct {
+		Name string `json:"name"`
+	}
+	rt := &Route{Method: http.MethodGet, Path: "/users", ResponseType: reflect.TypeOf(user{}), Envelope: true}
+	s := &schemas{defs: map[string]*Schema{}, names: map[reflect.Type]string{}}
+	op := s.operation(rt)
+	sc := op.Responses["200"].Content["application/json"].Schema
+	if sc.Properties["data"] == nil || sc.Properties["code"] == nil {
+		t.Fatalf("response schema = %+v, want data/code envelope", sc)
+	}
+}
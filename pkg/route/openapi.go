@@ -0,0 +1,434 @@
+package route
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Info OpenAPI 文档信息
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Document OpenAPI 3.0 文档（仅包含路由表用到的部分）
+type Document struct {
+	OpenAPI    string                           `json:"openapi"`
+	Info       Info                             `json:"info"`
+	Paths      map[string]map[string]*Operation `json:"paths"`
+	Components Components                       `json:"components"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas,omitempty"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type         string `json:"type"`
+	Scheme       string `json:"scheme"`
+	BearerFormat string `json:"bearerFormat,omitempty"`
+}
+
+type Operation struct {
+	OperationID string                `json:"operationId,omitempty"`
+	Summary     string                `json:"summary,omitempty"`
+	Description string                `json:"description,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+
+	Permissions []string `json:"x-permissions,omitempty"`
+	RateLimit   string   `json:"x-rate-limit,omitempty"`
+	CacheTTL    string   `json:"x-cache-ttl,omitempty"`
+}
+
+type Parameter struct {
+	Name     string  `json:"name"`
+	In       string  `json:"in"`
+	Required bool    `json:"required,omitempty"`
+	Schema   *Schema `json:"schema"`
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+}
+
+const bearerAuth = "bearerAuth"
+
+// OpenAPI 根据路由表生成文档。请求类型中带 uri、form、header 标签的字段生成路径、查询、请求头参数，
+// 其余字段按 json 标签生成请求体；binding:"required" 的字段标记为必填
+func (t *Table) OpenAPI(info Info) *Document {
+	doc := &Document{
+		OpenAPI: "3.0.3",
+		Info:    info,
+		Paths:   make(map[string]map[string]*Operation),
+		Components: Components{
+			Schemas: make(map[string]*Schema),
+		},
+	}
+	s := &schemas{defs: doc.Components.Schemas, names: make(map[reflect.Type]string)}
+	ids := make(map[string]int)
+
+	for _, rt := range t.Routes() {
+		p := openAPIPath(rt.Path)
+		if doc.Paths[p] == nil {
+			doc.Paths[p] = make(map[string]*Operation)
+		}
+		op := s.operation(rt)
+		// 同一处理函数挂载到多个路由时 operationId 加序号保持唯一
+		if n := ids[op.OperationID]; n > 0 {
+			ids[op.OperationID]++
+			op.OperationID += strconv.Itoa(n + 1)
+		} else {
+			ids[op.OperationID] = 1
+		}
+		doc.Paths[p][strings.ToLower(rt.Method)] = op
+		if rt.AuthRequired && doc.Components.SecuritySchemes == nil {
+			doc.Components.SecuritySchemes = map[string]*SecurityScheme{
+				bearerAuth: {Type: "http", Scheme: "bearer", BearerFormat: "JWT"},
+			}
+		}
+	}
+	return doc
+}
+
+func (s *schemas) operation(rt *Route) *Operation {
+	op := &Operation{
+		OperationID: operationID(rt.Handler),
+		Summary:     rt.Summary,
+		Description: rt.Description,
+		Tags:        rt.Tags,
+		Deprecated:  rt.Deprecated,
+		Responses:   make(map[string]*Response),
+		Permissions: rt.Permissions,
+		RateLimit:   rt.RateLimitPolicy,
+	}
+	if rt.CacheTTL > 0 {
+		op.CacheTTL = rt.CacheTTL.String()
+	}
+
+	declared := make(map[string]bool)
+	if rt.RequestType != nil {
+		params, body := s.request(rt.RequestType)
+		for _, param := range params {
+			if param.In == "path" {
+				declared[param.Name] = true
+			}
+		}
+		op.Parameters = params
+		if body != nil && rt.Method != http.MethodGet && rt.Method != http.MethodHead && rt.Method != http.MethodDelete {
+			op.RequestBody = &RequestBody{
+				Required: true,
+				Content:  map[string]*MediaType{"application/json": {Schema: body}},
+			}
+		}
+	}
+	// 路径中未在请求类型里声明的参数按字符串处理
+	for _, name := range pathParams(rt.Path) {
+		if !declared[name] {
+			op.Parameters = append(op.Parameters, &Parameter{Name: name, In: "path", Required: true, Schema: &Schema{Type: "string"}})
+		}
+	}
+
+	status := rt.ResponseStatus
+	if status == 0 {
+		status = http.StatusOK
+	}
+	resp := &Response{Description: http.StatusText(status)}
+	if rt.ResponseType != nil {
+		body := s.schema(rt.ResponseType)
+		if rt.Envelope {
+			body = dataSchema(body)
+		}
+		resp.Content = map[string]*MediaType{"application/json": {Schema: body}}
+	}
+	op.Responses[strconv.Itoa(status)] = resp
+
+	if rt.RequestType != nil {
+		op.Responses["400"] = s.errorResponse(http.StatusBadRequest)
+	}
+	if rt.AuthRequired {
+		op.Security = []map[string][]string{{bearerAuth: {}}}
+		op.Responses["401"] = s.errorResponse(http.StatusUnauthorized)
+	}
+	if len(rt.Permissions) > 0 {
+		op.Responses["403"] = s.errorResponse(http.StatusForbidden)
+	}
+	if rt.RateLimitPolicy != "" {
+		op.Responses["429"] = s.errorResponse(http.StatusTooManyRequests)
+	}
+	return op
+}
+
+// errorResponse 错误响应，响应体为 ErrorBody
+func (s *schemas) errorResponse(status int) *Response {
+	return &Response{
+		Description: http.StatusText(status),
+		Content:     map[string]*MediaType{"application/json": {Schema: s.schema(reflect.TypeOf(ErrorBody{}))}},
+	}
+}
+
+// dataSchema 成功响应的 DataBody 包装，data 为响应类型
+func dataSchema(data *Schema) *Schema {
+	return &Schema{
+		Type: "object",
+		Properties: map[string]*Schema{
+			"data": data,
+			"code": {Type: "integer"},
+		},
+		Required: []string{"data", "code"},
+	}
+}
+
+// openAPIPath /users/:id -> /users/{id}
+func openAPIPath(p string) string {
+	segments := strings.Split(p, "/")
+	for i, seg := range segments {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			segments[i] = "{" + seg[1:] + "}"
+		}
+	}
+	return strings.Join(segments, "/")
+}
+
+func pathParams(p string) []string {
+	var names []string
+	for _, seg := range strings.Split(p, "/") {
+		if strings.HasPrefix(seg, ":") || strings.HasPrefix(seg, "*") {
+			names = append(names, seg[1:])
+		}
+	}
+	return names
+}
+
+// operationID controller.(*UserController).GetUser -> UserController.GetUser
+func operationID(handler string) string {
+	r := strings.NewReplacer("(", "", ")", "", "*", "")
+	id := r.Replace(handler)
+	if i := strings.Index(id, "."); i >= 0 && strings.Count(id, ".") > 1 {
+		id = id[i+1:]
+	}
+	return id
+}
+
+// schemas 按类型生成 JSON Schema，具名结构体放入 components/schemas 并以 $ref 引用（可处理自引用）
+type schemas struct {
+	defs  map[string]*Schema
+	names map[reflect.Type]string
+}
+
+var (
+	timeType      = reflect.TypeOf(time.Time{})
+	marshalerType = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+func (s *schemas) schema(t reflect.Type) *Schema {
+	nullable := false
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+		nullable = true
+	}
+	var sc *Schema
+	switch {
+	case t == timeType:
+		sc = &Schema{Type: "string", Format: "date-time"}
+	case t.Implements(marshalerType) || reflect.PointerTo(t).Implements(marshalerType):
+		// 自定义序列化（例如 gorm.DeletedAt）无法从字段推断
+		sc = &Schema{}
+	case t.Kind() == reflect.Struct && t.Name() != "":
+		sc = &Schema{Ref: "#/components/schemas/" + s.define(t)}
+		return sc
+	case t.Kind() == reflect.Struct:
+		sc = s.object(t)
+	default:
+		sc = s.basic(t)
+	}
+	sc.Nullable = nullable
+	return sc
+}
+
+func (s *schemas) basic(t reflect.Type) *Schema {
+	switch t.Kind() {
+	case reflect.Bool:
+		return &Schema{Type: "boolean"}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return &Schema{Type: "integer", Format: "int32"}
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32:
+		return &Schema{Type: "number", Format: "float"}
+	case reflect.Float64:
+		return &Schema{Type: "number", Format: "double"}
+	case reflect.String:
+		return &Schema{Type: "string"}
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return &Schema{Type: "string", Format: "byte"}
+		}
+		return &Schema{Type: "array", Items: s.schema(t.Elem())}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: s.schema(t.Elem())}
+	}
+	// interface{} 等任意值
+	return &Schema{}
+}
+
+// define 注册具名结构体，同名不同包的类型以包名区分
+func (s *schemas) define(t reflect.Type) string {
+	if name, ok := s.names[t]; ok {
+		return name
+	}
+	name := t.Name()
+	if _, taken := s.defs[name]; taken {
+		pkg := t.PkgPath()
+		name = pkg[strings.LastIndex(pkg, "/")+1:] + "." + name
+	}
+	s.names[t] = name
+	s.defs[name] = &Schema{} // 先占位，递归引用自身时直接返回名称
+	*s.defs[name] = *s.object(t)
+	return name
+}
+
+func (s *schemas) object(t reflect.Type) *Schema {
+	sc := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.fields(t, func(f reflect.StructField, name string, required bool) {
+		sc.Properties[name] = s.schema(f.Type)
+		if required {
+			sc.Required = append(sc.Required, name)
+		}
+	}, false)
+	return sc
+}
+
+// request 拆分请求类型为参数和请求体
+func (s *schemas) request(t reflect.Type) ([]*Parameter, *Schema) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, s.schema(t)
+	}
+
+	var params []*Parameter
+	for _, loc := range []struct{ tag, in string }{{"uri", "path"}, {"header", "header"}, {"form", "query"}} {
+		for i := 0; i < t.NumField(); i++ {
+			s.param(t.Field(i), loc.tag, loc.in, &params)
+		}
+	}
+
+	body := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	s.fields(t, func(f reflect.StructField, name string, required bool) {
+		body.Properties[name] = s.schema(f.Type)
+		if required {
+			body.Required = append(body.Required, name)
+		}
+	}, true)
+	if len(body.Properties) == 0 {
+		return params, nil
+	}
+	return params, body
+}
+
+func (s *schemas) param(f reflect.StructField, tag, in string, params *[]*Parameter) {
+	if !f.IsExported() {
+		return
+	}
+	if f.Anonymous && f.Tag.Get(tag) == "" {
+		t := f.Type
+		for t.Kind() == reflect.Ptr {
+			t = t.Elem()
+		}
+		if t.Kind() == reflect.Struct {
+			for i := 0; i < t.NumField(); i++ {
+				s.param(t.Field(i), tag, in, params)
+			}
+		}
+		return
+	}
+	name := tagName(f.Tag.Get(tag))
+	if name == "" || name == "-" {
+		return
+	}
+	*params = append(*params, &Parameter{
+		Name:     name,
+		In:       in,
+		Required: in == "path" || required(f),
+		Schema:   s.schema(f.Type),
+	})
+}
+
+// fields 遍历结构体的 JSON 字段，匿名嵌入的结构体展开；skipParams 时跳过 uri、form、header 字段
+func (s *schemas) fields(t reflect.Type, fn func(f reflect.StructField, name string, required bool), skipParams bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+		if skipParams && (f.Tag.Get("uri") != "" || f.Tag.Get("form") != "" || f.Tag.Get("header") != "") {
+			continue
+		}
+		jsonTag := f.Tag.Get("json")
+		if jsonTag == "-" {
+			continue
+		}
+		name := tagName(jsonTag)
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			for ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				s.fields(ft, fn, skipParams)
+				continue
+			}
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fn(f, name, required(f))
+	}
+}
+
+func tagName(tag string) string {
+	name, _, _ := strings.Cut(tag, ",")
+	return name
+}
+
+func required(f reflect.StructField) bool {
+	for _, rule := range strings.Split(f.Tag.Get("binding"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
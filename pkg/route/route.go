@@ -0,0 +1,319 @@
+// Package route 声明式路由：在注册路由的同时记录认证、权限、限流、缓存和文档等元数据，
+// 路由表用于挂载到 gin、生成 OpenAPI 文档和 turbo routes 列表
+//
+//	r.GET("/users/:id", ctl.GetUser).Auth().Permission("user:read").RateLimit("strict").Doc("查询用户")
+package route
+
+import (
+	"fmt"
+	"net/http"
+	"path"
+	"reflect"
+	"runtime"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+)
+
+// Route 一条路由及其元数据，链式方法在 Mount 之前调用
+type Route struct {
+	Method          string        `json:"method"`
+	Path            string        `json:"path"`
+	Handler         string        `json:"handler"`
+	Summary         string        `json:"summary,omitempty"`
+	Description     string        `json:"description,omitempty"`
+	Tags            []string      `json:"tags,omitempty"`
+	AuthRequired    bool          `json:"auth,omitempty"`
+	Permissions     []string      `json:"permissions,omitempty"`
+	RateLimitPolicy string        `json:"rate_limit,omitempty"`
+	CacheTTL        time.Duration `json:"cache_ttl,omitempty"`
+	Deprecated      bool          `json:"deprecated,omitempty"`
+
+	// RequestType、ResponseType 请求与响应的类型，用于生成 OpenAPI 文档
+	RequestType    reflect.Type `json:"-"`
+	ResponseType   reflect.Type `json:"-"`
+	ResponseStatus int          `json:"-"`
+	// Envelope 响应类型包装在 DataBody 中返回（通过 route.GET 等注册的类型化处理函数）
+	Envelope bool `json:"-"`
+
+	middleware []gin.HandlerFunc // 分组中间件
+	use        []gin.HandlerFunc // 路由中间件
+	handler    gin.HandlerFunc
+}
+
+// Auth 要求携带有效的JWT
+func (rt *Route) Auth() *Route {
+	rt.AuthRequired = true
+	return rt
+}
+
+// Permission 要求令牌包含全部权限，隐含 Auth
+func (rt *Route) Permission(perms ...string) *Route {
+	rt.AuthRequired = true
+	rt.Permissions = append(rt.Permissions, perms...)
+	return rt
+}
+
+// RateLimit 使用具名限流策略（MIDDLEWARE.RATE_LIMIT.POLICIES）
+func (rt *Route) RateLimit(policy string) *Route {
+	rt.RateLimitPolicy = policy
+	return rt
+}
+
+// Cache 成功的 GET 响应设置 Cache-Control: max-age，需认证的路由使用 private
+func (rt *Route) Cache(ttl time.Duration) *Route {
+	rt.CacheTTL = ttl
+	return rt
+}
+
+// Doc 文档摘要，可选的第二个参数为详细说明
+func (rt *Route) Doc(summary string, description ...string) *Route {
+	rt.Summary = summary
+	rt.Description = strings.Join(description, "\n\n")
+	return rt
+}
+
+// Tag 文档分组，默认使用模块名称
+func (rt *Route) Tag(tags ...string) *Route {
+	rt.Tags = tags
+	return rt
+}
+
+// Deprecate 在文档中标记为已废弃
+func (rt *Route) Deprecate() *Route {
+	rt.Deprecated = true
+	return rt
+}
+
+// Request 声明请求类型（传入零值，例如 model.User{}），用于生成文档
+func (rt *Route) Request(v interface{}) *Route {
+	rt.RequestType = reflect.TypeOf(v)
+	return rt
+}
+
+// Response 声明成功时的状态码和响应类型，v 为nil时表示没有响应体
+func (rt *Route) Response(status int, v interface{}) *Route {
+	rt.ResponseStatus = status
+	if v != nil {
+		rt.ResponseType = reflect.TypeOf(v)
+	}
+	return rt
+}
+
+// Use 追加仅作用于该路由的中间件
+func (rt *Route) Use(middleware ...gin.HandlerFunc) *Route {
+	rt.use = append(rt.use, middleware...)
+	return rt
+}
+
+// Table 路由表
+type Table struct {
+	mu     sync.Mutex
+	routes []*Route
+}
+
+func (t *Table) add(rt *Route) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.routes = append(t.routes, rt)
+}
+
+// Routes 按路径、方法排序的全部路由
+func (t *Table) Routes() []*Route {
+	t.mu.Lock()
+	routes := append([]*Route(nil), t.routes...)
+	t.mu.Unlock()
+
+	sort.SliceStable(routes, func(i, j int) bool {
+		if routes[i].Path != routes[j].Path {
+			return routes[i].Path < routes[j].Path
+		}
+		return methodOrder(routes[i].Method) < methodOrder(routes[j].Method)
+	})
+	return routes
+}
+
+// Lookup 按方法和路径（gin写法，例如 /v1/users/:id）查找路由
+func (t *Table) Lookup(method, path string) (*Route, bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	for _, rt := range t.routes {
+		if rt.Method == method && rt.Path == path {
+			return rt, true
+		}
+	}
+	return nil, false
+}
+
+func methodOrder(method string) int {
+	for i, m := range []string{http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions} {
+		if m == method {
+			return i
+		}
+	}
+	return 99
+}
+
+// Router 路由构建器，与 gin.RouterGroup 用法相同，注册的路由在 Mount 时才挂载到 gin
+type Router struct {
+	table      *Table
+	auth       *middleware.Auth
+	limiter    *middleware.RateLimiter
+	basePath   string
+	middleware []gin.HandlerFunc
+	tags       []string
+	authAll    bool
+}
+
+// New 创建路由构建器；auth 为nil（未启用JWT）时需要认证的路由无法挂载，
+// limiter 为nil时引用限流策略的路由无法挂载
+func New(auth *middleware.Auth, limiter *middleware.RateLimiter) *Router {
+	return &Router{table: &Table{}, auth: auth, limiter: limiter, basePath: "/"}
+}
+
+// Table 已注册的路由
+func (r *Router) Table() *Table {
+	return r.table
+}
+
+// Group 创建子分组，继承父分组的中间件、标签和认证要求
+func (r *Router) Group(relativePath string, middleware ...gin.HandlerFunc) *Router {
+	g := *r
+	g.basePath = joinPaths(r.basePath, relativePath)
+	g.middleware = append(append([]gin.HandlerFunc(nil), r.middleware...), middleware...)
+	g.tags = append([]string(nil), r.tags...)
+	return &g
+}
+
+// Use 为分组追加中间件，只影响之后注册的路由
+func (r *Router) Use(middleware ...gin.HandlerFunc) *Router {
+	r.middleware = append(r.middleware, middleware...)
+	return r
+}
+
+// Auth 分组内之后注册的路由都要求认证
+func (r *Router) Auth() *Router {
+	r.authAll = true
+	return r
+}
+
+// Tag 分组内之后注册的路由的默认文档分组
+func (r *Router) Tag(tags ...string) *Router {
+	r.tags = tags
+	return r
+}
+
+// Handle 注册路由
+func (r *Router) Handle(method, relativePath string, handler gin.HandlerFunc) *Route {
+	rt := &Route{
+		Method:       method,
+		Path:         joinPaths(r.basePath, relativePath),
+		Handler:      funcName(handler),
+		Tags:         append([]string(nil), r.tags...),
+		AuthRequired: r.authAll,
+		middleware:   append([]gin.HandlerFunc(nil), r.middleware...),
+		handler:      handler,
+	}
+	r.table.add(rt)
+	return rt
+}
+
+func (r *Router) GET(path string, handler gin.HandlerFunc) *Route {
+	return r.Handle(http.MethodGet, path, handler)
+}
+
+func (r *Router) POST(path string, handler gin.HandlerFunc) *Route {
+	return r.Handle(http.MethodPost, path, handler)
+}
+
+func (r *Router) PUT(path string, handler gin.HandlerFunc) *Route {
+	return r.Handle(http.MethodPut, path, handler)
+}
+
+func (r *Router) PATCH(path string, handler gin.HandlerFunc) *Route {
+	return r.Handle(http.MethodPatch, path, handler)
+}
+
+func (r *Router) DELETE(path string, handler gin.HandlerFunc) *Route {
+	return r.Handle(http.MethodDelete, path, handler)
+}
+
+// Validate 校验路由引用的限流策略均已配置，Mount 时自动执行
+func (r *Router) Validate() error {
+	for _, rt := range r.table.Routes() {
+		if rt.RateLimitPolicy == "" {
+			continue
+		}
+		if _, err := r.limiter.Policy(rt.RateLimitPolicy); err != nil {
+			return fmt.Errorf("route %s %s: %w", rt.Method, rt.Path, err)
+		}
+	}
+	return nil
+}
+
+// Mount 按元数据组装中间件链并挂载全部路由，执行顺序：
+// 分组中间件 -> 限流策略 -> 认证 -> 权限 -> 缓存 -> 路由中间件 -> 处理函数；
+// 未启用JWT时存在需要认证或权限的路由则返回错误，而不是将其公开
+func (r *Router) Mount(engine gin.IRoutes) error {
+	for _, rt := range r.table.Routes() {
+		if rt.AuthRequired && r.auth == nil {
+			return fmt.Errorf("route %s %s requires authentication but JWT is disabled (JWT.ENABLED=false)", rt.Method, rt.Path)
+		}
+	}
+
+	for _, rt := range r.table.Routes() {
+		chain := append([]gin.HandlerFunc(nil), rt.middleware...)
+		if rt.RateLimitPolicy != "" {
+			limit, err := r.limiter.Policy(rt.RateLimitPolicy)
+			if err != nil {
+				return fmt.Errorf("route %s %s: %w", rt.Method, rt.Path, err)
+			}
+			chain = append(chain, limit)
+		}
+		if rt.AuthRequired {
+			chain = append(chain, r.auth.Middleware())
+		}
+		if len(rt.Permissions) > 0 {
+			chain = append(chain, r.auth.RequirePermissions(rt.Permissions...))
+		}
+		if rt.CacheTTL > 0 {
+			chain = append(chain, middleware.CacheControl(rt.CacheTTL, rt.AuthRequired))
+		}
+		chain = append(chain, rt.use...)
+		engine.Handle(rt.Method, rt.Path, append(chain, rt.handler)...)
+	}
+	return nil
+}
+
+// joinPaths 与 gin 的分组路径拼接规则一致，保留末尾的斜杠
+func joinPaths(absolutePath, relativePath string) string {
+	if relativePath == "" {
+		return absolutePath
+	}
+	finalPath := path.Join(absolutePath, relativePath)
+	if strings.HasSuffix(relativePath, "/") && !strings.HasSuffix(finalPath, "/") {
+		return finalPath + "/"
+	}
+	return finalPath
+}
+
+// funcName 处理函数名，去掉包路径前缀和方法值后缀，例如 controller.(*UserController).GetUser
+func funcName(h interface{}) string {
+	v := reflect.ValueOf(h)
+	if v.Kind() != reflect.Func || v.IsNil() {
+		return ""
+	}
+	fn := runtime.FuncForPC(v.Pointer())
+	if fn == nil {
+		return ""
+	}
+	name := strings.TrimSuffix(fn.Name(), "-fm")
+	if i := strings.LastIndex(name, "/"); i >= 0 {
+		name = name[i+1:]
+	}
+	return name
+}
@@ -0,0 +1,112 @@
+package route
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/middleware"
+)
+
+func ok(c *gin.Context) { c.Status(http.StatusOK) }
+
+func newLimiter(enabled bool) *middleware.RateLimiter {
+	cfg := &config.Config{}
+	cfg.Middleware.RateLimit.Enabled = enabled
+	cfg.Middleware.RateLimit.RPS = 100
+	cfg.Middleware.RateLimit.Burst = 100
+	cfg.Middleware.RateLimit.Policies = map[string]config.RateLimitPolicy{"strict": {RPS: 1, Burst: 1}}
+	return middleware.NewRateLimiter(cfg)
+}
+
+// 未启用JWT时需要认证的路由不能被公开挂载
+func TestMountWithoutAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name     string
+		register func(r *Router)
+		wantErr  bool
+	}{
+		{name: "public route", register: func(r *Router) { r.GET("/public", ok) }},
+		{name: "route auth", register: func(r *Router) { r.GET("/me", ok).Auth() }, wantErr: true},
+		{name: "group auth", register: func(r *Router) { r.Group("/users").Auth().GET("/:id", ok) }, wantErr: true},
+		{name: "permission", register: func(r *Router) { r.DELETE("/users/:id", ok).Permission("user:delete") }, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(nil, newLimiter(false))
+			tt.register(r)
+			err := r.Mount(gin.New())
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("Mount = %v, want error %v", err, tt.wantErr)
+			}
+			if err != nil && !strings.Contains(err.Error(), "JWT is disabled") {
+				t.Fatalf("Mount = %v, want it to name JWT", err)
+			}
+		})
+	}
+}
+
+func TestRequirePermissionsWithoutAuthDenies(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	var auth *middleware.Auth
+	engine := gin.New()
+	engine.GET("/", auth.RequirePermissions("user:read"), ok)
+
+	w := httptest.NewRecorder()
+	engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	if w.Code != http.StatusForbidden {
+		t.Fatalf("status = %d, want 403", w.Code)
+	}
+}
+
+// 限流策略名称在任何情况下都按配置校验
+func TestRateLimitPolicyValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	tests := []struct {
+		name    string
+		limiter *middleware.RateLimiter
+		policy  string
+		wantErr bool
+	}{
+		{name: "enabled, known", limiter: newLimiter(true), policy: "strict"},
+		{name: "enabled, unknown", limiter: newLimiter(true), policy: "strcit", wantErr: true},
+		{name: "disabled, known", limiter: newLimiter(false), policy: "strict"},
+		{name: "disabled, unknown", limiter: newLimiter(false), policy: "strcit", wantErr: true},
+		{name: "no limiter", policy: "strict", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := New(nil, tt.limiter)
+			r.GET("/search", ok).RateLimit(tt.policy)
+
+			if err := r.Validate(); tt.wantErr != (err != nil) {
+				t.Fatalf("Validate = %v, want error %v", err, tt.wantErr)
+			}
+			if err := r.Mount(gin.New()); tt.wantErr != (err != nil) {
+				t.Fatalf("Mount = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRateLimitPolicyDisabledPassesThrough(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	r := New(nil, newLimiter(false))
+	r.GET("/search", ok).RateLimit("strict")
+	if err := r.Mount(engine); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < 3; i++ {
+		w := httptest.NewRecorder()
+		engine.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/search", nil))
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d: status = %d, want 200 with rate limiting disabled", i, w.Code)
+		}
+	}
+}
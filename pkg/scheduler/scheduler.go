@@ -0,0 +1,320 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"runtime/debug"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/robfig/cron/v3"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/lock"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+)
+
+// TaskFunc 定时任务函数，ctx在失去leader身份或停止时取消
+type TaskFunc func(ctx context.Context) error
+
+// cron表达式支持可选的秒字段以及@every/@daily等描述符
+var parser = cron.NewParser(
+	cron.SecondOptional | cron.Minute | cron.Hour | cron.Dom | cron.Month | cron.Dow | cron.Descriptor,
+)
+
+type task struct {
+	name     string
+	spec     string
+	schedule cron.Schedule
+	fn       TaskFunc
+}
+
+// Scheduler 定时任务调度器，多副本部署时仅leader执行任务
+type Scheduler struct {
+	cfg      *config.SchedulerConfig
+	locker   lock.Locker
+	store    StatusStore
+	log      *logger.Logger
+	location *time.Location
+	instance string
+
+	mu      sync.Mutex
+	tasks   []*task
+	cancel  context.CancelFunc
+	done    chan struct{}
+	started bool
+}
+
+// New 构造函数
+func New(cfg *config.Config, lc *app.Lifecycle, locker lock.Locker, client *redis.Client, log *logger.Logger) (*Scheduler, error) {
+	if !cfg.Scheduler.Enabled {
+		return nil, nil
+	}
+	if locker == nil {
+		return nil, errors.New("scheduler: leader election requires a lock, enable REDIS or set LOCK.BACKEND=memory for a single instance")
+	}
+
+	location := time.Local
+	if cfg.Scheduler.Timezone != "" {
+		loc, err := time.LoadLocation(cfg.Scheduler.Timezone)
+		if err != nil {
+			return nil, fmt.Errorf("scheduler: invalid timezone: %w", err)
+		}
+		location = loc
+	}
+
+	hostname, _ := os.Hostname()
+	s := &Scheduler{
+		cfg:      &cfg.Scheduler,
+		locker:   locker,
+		store:    NewStatusStore(client, cfg.Scheduler.LeaderKey+":status"),
+		log:      log.WithFields(logger.String("component", "scheduler")),
+		location: location,
+		instance: fmt.Sprintf("%s-%d", hostname, os.Getpid()),
+	}
+
+	lc.Append(app.Hook{
+		Name:    "scheduler",
+		OnStart: func(context.Context) error { return s.Start() },
+		OnStop:  s.Stop,
+	})
+	return s, nil
+}
+
+// Cron 按cron表达式注册任务，例如 "0 3 * * *"、"*/10 * * * * *"、"@hourly"
+func (s *Scheduler) Cron(name, spec string, fn TaskFunc) error {
+	schedule, err := parser.Parse(spec)
+	if err != nil {
+		return fmt.Errorf("scheduler: parse %q for task %q: %w", spec, name, err)
+	}
+	return s.add(&task{name: name, spec: spec, schedule: schedule, fn: fn})
+}
+
+// Every 按固定间隔注册任务
+func (s *Scheduler) Every(name string, interval time.Duration, fn TaskFunc) error {
+	if interval <= 0 {
+		return fmt.Errorf("scheduler: invalid interval %s for task %q", interval, name)
+	}
+	return s.add(&task{name: name, spec: "@every " + interval.String(), schedule: every(interval), fn: fn})
+}
+
+// every 固定间隔调度（cron.Every会将间隔取整到秒）
+type every time.Duration
+
+func (e every) Next(t time.Time) time.Time {
+	return t.Add(time.Duration(e))
+}
+
+func (s *Scheduler) add(t *task) error {
+	if s == nil {
+		return fmt.Errorf("scheduler: disabled, cannot register task %q", t.name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("scheduler: cannot register task %q after start", t.name)
+	}
+	for _, existing := range s.tasks {
+		if existing.name == t.name {
+			return fmt.Errorf("scheduler: task %q already registered", t.name)
+		}
+	}
+	s.tasks = append(s.tasks, t)
+	return nil
+}
+
+// Statuses 查看所有任务的运行状态
+func (s *Scheduler) Statuses(ctx context.Context) ([]TaskStatus, error) {
+	if s == nil {
+		return nil, nil
+	}
+	list, err := s.store.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list, nil
+}
+
+// Start 启动选主与调度（非阻塞）
+func (s *Scheduler) Start() error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.started {
+		return fmt.Errorf("scheduler: already started")
+	}
+	s.started = true
+
+	ctx, cancel := context.WithCancel(context.Background())
+	s.cancel = cancel
+	s.done = make(chan struct{})
+
+	go s.campaign(ctx)
+
+	s.log.Info("Scheduler started",
+		logger.Int("tasks", len(s.tasks)),
+		logger.String("instance", s.instance))
+	return nil
+}
+
+// Stop 停止调度并等待正在执行的任务结束
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s == nil {
+		return nil
+	}
+
+	s.mu.Lock()
+	if !s.started || s.cancel == nil {
+		s.mu.Unlock()
+		return nil
+	}
+	s.cancel()
+	s.cancel = nil
+	s.mu.Unlock()
+
+	select {
+	case <-s.done:
+		s.log.Info("Scheduler stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("scheduler: stop: %w", ctx.Err())
+	}
+}
+
+// campaign 竞选leader，成为leader后运行所有任务，失去leader身份后重新竞选
+func (s *Scheduler) campaign(ctx context.Context) {
+	defer close(s.done)
+
+	for {
+		leader, err := s.locker.Obtain(ctx, s.cfg.LeaderKey, s.cfg.LeaderTTL,
+			lock.WithRetry(lock.LinearBackoff(s.cfg.LeaderTTL/3)))
+		if err != nil {
+			if ctx.Err() != nil {
+				return
+			}
+			s.log.Warn("Leader election failed", logger.Error(err))
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(s.cfg.LeaderTTL / 3):
+			}
+			continue
+		}
+
+		s.log.Info("Became scheduler leader", logger.Int64("fence", leader.Fence()))
+		s.lead(ctx, leader)
+
+		releaseCtx, cancel := context.WithTimeout(context.Background(), time.Second)
+		_ = leader.Release(releaseCtx)
+		cancel()
+
+		if ctx.Err() != nil {
+			return
+		}
+		s.log.Warn("Lost scheduler leadership")
+	}
+}
+
+// lead 以leader身份运行任务，直到停止或锁丢失
+func (s *Scheduler) lead(ctx context.Context, leader lock.Lock) {
+	leaderCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, t := range s.tasks {
+		wg.Add(1)
+		go func(t *task) {
+			defer wg.Done()
+			s.loop(leaderCtx, t)
+		}(t)
+	}
+
+	select {
+	case <-ctx.Done():
+	case <-leader.Lost():
+	}
+	cancel()
+	wg.Wait()
+}
+
+// loop 按计划循环执行单个任务，同一任务不会并发执行
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	for {
+		next := t.schedule.Next(time.Now().In(s.location))
+		s.saveNext(ctx, t, next)
+
+		timer := time.NewTimer(time.Until(next))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+
+		s.run(ctx, t)
+	}
+}
+
+// run 执行任务并记录状态
+func (s *Scheduler) run(ctx context.Context, t *task) {
+	status := s.status(ctx, t)
+	start := time.Now()
+
+	err := safeRun(ctx, t.fn)
+
+	status.LastRun = start
+	status.LastDuration = time.Since(start)
+	status.Runs++
+	status.LastError = ""
+	status.Instance = s.instance
+	fields := []logger.Field{logger.String("task", t.name), logger.Duration("duration", status.LastDuration)}
+	if err != nil {
+		status.Failures++
+		status.LastError = err.Error()
+		s.log.Error("Scheduled task failed", append(fields, logger.Error(err))...)
+	} else {
+		s.log.Debug("Scheduled task finished", fields...)
+	}
+
+	if err := s.store.Save(context.WithoutCancel(ctx), status); err != nil {
+		s.log.Warn("Save task status failed", logger.String("task", t.name), logger.Error(err))
+	}
+}
+
+func (s *Scheduler) saveNext(ctx context.Context, t *task, next time.Time) {
+	status := s.status(ctx, t)
+	status.NextRun = next
+	if err := s.store.Save(ctx, status); err != nil && ctx.Err() == nil {
+		s.log.Warn("Save task status failed", logger.String("task", t.name), logger.Error(err))
+	}
+}
+
+func (s *Scheduler) status(ctx context.Context, t *task) TaskStatus {
+	status, ok, err := s.store.Load(ctx, t.name)
+	if err != nil || !ok {
+		status = TaskStatus{}
+	}
+	status.Name = t.name
+	status.Spec = t.spec
+	return status
+}
+
+// safeRun 执行任务并捕获panic
+func safeRun(ctx context.Context, fn TaskFunc) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("task panic: %v\n%s", r, debug.Stack())
+		}
+	}()
+	return fn(ctx)
+}
@@ -0,0 +1,146 @@
+package scheduler
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/lock"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+func newScheduler(t *testing.T, locker lock.Locker) *Scheduler {
+	t.Helper()
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{
+		Enabled:   true,
+		LeaderKey: "scheduler:leader",
+		LeaderTTL: time.Second,
+	}}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	s, err := New(cfg, lc, locker, nil, logger.NewNop())
+	if err != nil {
+		t.Fatalf("New: %v", err)
+	}
+	return s
+}
+
+func stop(t *testing.T, s *Scheduler) {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := s.Stop(ctx); err != nil {
+		t.Fatalf("Stop: %v", err)
+	}
+}
+
+func TestRequiresLocker(t *testing.T) {
+	cfg := &config.Config{Scheduler: config.SchedulerConfig{Enabled: true, LeaderKey: "scheduler:leader", LeaderTTL: time.Second}}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	if _, err := New(cfg, lc, nil, nil, logger.NewNop()); err == nil {
+		t.Fatal("New without a locker should fail instead of running without leader election")
+	}
+}
+
+func TestDisabled(t *testing.T) {
+	cfg := &config.Config{}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	s, err := New(cfg, lc, lock.NewMemory(), nil, logger.NewNop())
+	if err != nil || s != nil {
+		t.Fatalf("New() = %v, %v; want nil, nil", s, err)
+	}
+	if err := s.Every("task", time.Second, func(context.Context) error { return nil }); err == nil {
+		t.Fatal("registering on a disabled scheduler should fail")
+	}
+}
+
+func TestRegister(t *testing.T) {
+	s := newScheduler(t, lock.NewMemory())
+	noop := func(context.Context) error { return nil }
+
+	if err := s.Cron("report", "0 3 * * *", noop); err != nil {
+		t.Fatalf("Cron: %v", err)
+	}
+	if err := s.Cron("bad", "not a cron", noop); err == nil {
+		t.Fatal("invalid cron expression accepted")
+	}
+	if err := s.Every("report", time.Minute, noop); err == nil {
+		t.Fatal("duplicate task name accepted")
+	}
+	if err := s.Every("zero", 0, noop); err == nil {
+		t.Fatal("zero interval accepted")
+	}
+
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	defer stop(t, s)
+	if err := s.Every("late", time.Minute, noop); err == nil {
+		t.Fatal("registering after start accepted")
+	}
+}
+
+func TestOnlyLeaderRuns(t *testing.T) {
+	locker := lock.NewMemory()
+	var runs [2]atomic.Int64
+	schedulers := make([]*Scheduler, 2)
+	for i := range schedulers {
+		s := newScheduler(t, locker)
+		i := i
+		if err := s.Every("tick", 10*time.Millisecond, func(context.Context) error {
+			runs[i].Add(1)
+			return nil
+		}); err != nil {
+			t.Fatalf("Every: %v", err)
+		}
+		if err := s.Start(); err != nil {
+			t.Fatalf("Start: %v", err)
+		}
+		schedulers[i] = s
+	}
+
+	time.Sleep(200 * time.Millisecond)
+	for _, s := range schedulers {
+		stop(t, s)
+	}
+
+	a, b := runs[0].Load(), runs[1].Load()
+	if a+b == 0 {
+		t.Fatal("task never ran")
+	}
+	if a != 0 && b != 0 {
+		t.Fatalf("both schedulers ran the task (%d, %d), want only the leader", a, b)
+	}
+}
+
+func TestStatusRecorded(t *testing.T) {
+	s := newScheduler(t, lock.NewMemory())
+	var calls atomic.Int64
+	if err := s.Every("fail", 10*time.Millisecond, func(context.Context) error {
+		if calls.Add(1) == 1 {
+			panic("boom")
+		}
+		return nil
+	}); err != nil {
+		t.Fatalf("Every: %v", err)
+	}
+	if err := s.Start(); err != nil {
+		t.Fatalf("Start: %v", err)
+	}
+	time.Sleep(100 * time.Millisecond)
+	stop(t, s)
+
+	statuses, err := s.Statuses(context.Background())
+	if err != nil {
+		t.Fatalf("Statuses: %v", err)
+	}
+	if len(statuses) != 1 {
+		t.Fatalf("got %d statuses, want 1", len(statuses))
+	}
+	st := statuses[0]
+	if st.Runs < 2 || st.Failures != 1 || st.LastError != "" {
+		t.Fatalf("status = %+v, want >=2 runs, 1 failure recovered from panic", st)
+	}
+}
@@ -0,0 +1,119 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	goredis "github.com/redis/go-redis/v9"
+
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+)
+
+// TaskStatus 任务运行状态
+type TaskStatus struct {
+	Name         string        `json:"name"`
+	Spec         string        `json:"spec"`
+	LastRun      time.Time     `json:"last_run"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+	NextRun      time.Time     `json:"next_run"`
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Instance     string        `json:"instance"`
+}
+
+// StatusStore 运行状态存储，Redis存储下任意副本都能查看leader上的执行记录
+type StatusStore interface {
+	Save(ctx context.Context, status TaskStatus) error
+	Load(ctx context.Context, name string) (TaskStatus, bool, error)
+	List(ctx context.Context) ([]TaskStatus, error)
+}
+
+// NewStatusStore 根据Redis是否启用选择实现
+func NewStatusStore(client *redis.Client, key string) StatusStore {
+	if client == nil {
+		return NewMemoryStatusStore()
+	}
+	return &redisStatusStore{cli: client.GetClient(), key: key}
+}
+
+type redisStatusStore struct {
+	cli goredis.UniversalClient
+	key string
+}
+
+func (s *redisStatusStore) Save(ctx context.Context, status TaskStatus) error {
+	data, err := json.Marshal(status)
+	if err != nil {
+		return fmt.Errorf("scheduler: encode status: %w", err)
+	}
+	return s.cli.HSet(ctx, s.key, status.Name, data).Err()
+}
+
+func (s *redisStatusStore) Load(ctx context.Context, name string) (TaskStatus, bool, error) {
+	var status TaskStatus
+	data, err := s.cli.HGet(ctx, s.key, name).Bytes()
+	if err == goredis.Nil {
+		return status, false, nil
+	}
+	if err != nil {
+		return status, false, err
+	}
+	if err := json.Unmarshal(data, &status); err != nil {
+		return status, false, fmt.Errorf("scheduler: decode status: %w", err)
+	}
+	return status, true, nil
+}
+
+func (s *redisStatusStore) List(ctx context.Context) ([]TaskStatus, error) {
+	all, err := s.cli.HGetAll(ctx, s.key).Result()
+	if err != nil {
+		return nil, err
+	}
+	list := make([]TaskStatus, 0, len(all))
+	for _, data := range all {
+		var status TaskStatus
+		if err := json.Unmarshal([]byte(data), &status); err != nil {
+			return nil, fmt.Errorf("scheduler: decode status: %w", err)
+		}
+		list = append(list, status)
+	}
+	return list, nil
+}
+
+type memoryStatusStore struct {
+	mu       sync.RWMutex
+	statuses map[string]TaskStatus
+}
+
+// NewMemoryStatusStore 创建进程内状态存储
+func NewMemoryStatusStore() StatusStore {
+	return &memoryStatusStore{statuses: make(map[string]TaskStatus)}
+}
+
+func (s *memoryStatusStore) Save(_ context.Context, status TaskStatus) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.statuses[status.Name] = status
+	return nil
+}
+
+func (s *memoryStatusStore) Load(_ context.Context, name string) (TaskStatus, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	status, ok := s.statuses[name]
+	return status, ok, nil
+}
+
+func (s *memoryStatusStore) List(_ context.Context) ([]TaskStatus, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	list := make([]TaskStatus, 0, len(s.statuses))
+	for _, status := range s.statuses {
+		list = append(list, status)
+	}
+	return list, nil
+}
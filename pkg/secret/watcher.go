@@ -0,0 +1,145 @@
+package secret
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// Watcher 定期重新解析配置中的密钥引用（file://、env://、vault:// 等），
+// 值变化时通知订阅者。没有引用或 SECRETS.REFRESH_INTERVAL 为0时为nil，方法均可安全调用
+type Watcher struct {
+	cfg      *config.Config
+	log      *logger.Logger
+	interval time.Duration
+
+	mu     sync.RWMutex
+	refs   []config.SecretRef
+	values map[string]string
+	subs   map[string][]func(value string)
+
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// NewWatcher 构造函数
+func NewWatcher(cfg *config.Config, lc *app.Lifecycle, log *logger.Logger) *Watcher {
+	refs := cfg.SecretRefs()
+	if len(refs) == 0 || cfg.Secrets.RefreshInterval <= 0 {
+		return nil
+	}
+
+	w := &Watcher{
+		cfg:      cfg,
+		log:      log.WithFields(logger.String("component", "secret")),
+		interval: cfg.Secrets.RefreshInterval,
+		refs:     refs,
+		values:   make(map[string]string, len(refs)),
+		subs:     make(map[string][]func(string)),
+	}
+	for _, ref := range refs {
+		w.values[ref.Key] = ref.Value
+	}
+
+	lc.Append(app.Hook{
+		Name: "secret-watcher",
+		OnStart: func(context.Context) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			w.cancel = cancel
+			w.done = make(chan struct{})
+			go w.loop(ctx)
+			return nil
+		},
+		OnStop: func(ctx context.Context) error {
+			if w.cancel == nil {
+				return nil
+			}
+			w.cancel()
+			select {
+			case <-w.done:
+			case <-ctx.Done():
+			}
+			return nil
+		},
+	})
+	return w
+}
+
+// Get 返回配置项当前的值，未通过引用加载时返回 fallback（通常为配置中的原值）
+func (w *Watcher) Get(key, fallback string) string {
+	if w == nil {
+		return fallback
+	}
+	w.mu.RLock()
+	defer w.mu.RUnlock()
+	if value, ok := w.values[key]; ok {
+		return value
+	}
+	return fallback
+}
+
+// OnChange 订阅配置项的轮换，fn 在刷新协程中调用，不应阻塞
+func (w *Watcher) OnChange(key string, fn func(value string)) {
+	if w == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subs[key] = append(w.subs[key], fn)
+}
+
+// Refresh 立即重新解析全部引用
+func (w *Watcher) Refresh(ctx context.Context) {
+	if w == nil {
+		return
+	}
+	for _, ref := range w.refs {
+		value, err := w.cfg.ResolveSecret(ctx, ref.Ref)
+		if err != nil {
+			// 保留旧值，下个周期重试
+			w.log.Warn("Secret refresh failed",
+				logger.String("key", ref.Key),
+				logger.String("ref", ref.Ref),
+				logger.Error(err))
+			continue
+		}
+
+		w.mu.Lock()
+		changed := w.values[ref.Key] != value
+		if changed {
+			w.values[ref.Key] = value
+		}
+		subs := append([]func(string){}, w.subs[ref.Key]...)
+		w.mu.Unlock()
+		if !changed {
+			continue
+		}
+
+		if len(subs) == 0 {
+			w.log.Warn("Secret rotated, restart required to apply", logger.String("key", ref.Key))
+			continue
+		}
+		for _, fn := range subs {
+			fn(value)
+		}
+		w.log.Info("Secret rotated", logger.String("key", ref.Key))
+	}
+}
+
+func (w *Watcher) loop(ctx context.Context) {
+	defer close(w.done)
+	ticker := time.NewTicker(w.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			w.Refresh(ctx)
+		}
+	}
+}
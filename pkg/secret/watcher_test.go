@@ -0,0 +1,135 @@
+package secret
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+)
+
+// vaultStub 返回KV v2格式的JWT密钥，值可在测试中修改以模拟轮换
+type vaultStub struct {
+	mu     sync.Mutex
+	secret string
+	fail   bool
+}
+
+func (s *vaultStub) set(secret string, fail bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.secret, s.fail = secret, fail
+}
+
+func (s *vaultStub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.fail {
+		http.Error(w, "sealed", http.StatusServiceUnavailable)
+		return
+	}
+	if r.URL.Path != "/v1/secret/data/app" || r.Header.Get("X-Vault-Token") != "root" {
+		http.NotFound(w, r)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	_, _ = w.Write([]byte(`{"data":{"data":{"jwt_secret":"` + s.secret + `"},"metadata":{}}}`))
+}
+
+// loadConfig 通过 config.Load 加载引用 vault 密钥的配置
+func loadConfig(t *testing.T, vaultAddr string) *config.Config {
+	t.Helper()
+	file := filepath.Join(t.TempDir(), "config.yaml")
+	yaml := `
+DATABASE:
+  ENABLED: false
+MIDDLEWARE:
+  CORS:
+    ALLOW_ORIGINS: ["*"]
+JWT:
+  ENABLED: true
+  SECRET: "vault://secret/data/app#jwt_secret"
+SECRETS:
+  REFRESH_INTERVAL: 1h
+  VAULT:
+    ADDR: "` + vaultAddr + `"
+    TOKEN: "root"
+`
+	if err := os.WriteFile(file, []byte(yaml), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := config.Load(config.LoadOptions{File: file})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	return cfg
+}
+
+func TestWatcherRefreshRotation(t *testing.T) {
+	stub := &vaultStub{}
+	stub.set("first-secret-0123456789abcdefghijkl", false)
+	srv := httptest.NewServer(stub)
+	defer srv.Close()
+
+	cfg := loadConfig(t, srv.URL)
+	if cfg.JWT.Secret != "first-secret-0123456789abcdefghijkl" {
+		t.Fatalf("JWT.SECRET = %q, want value resolved from vault", cfg.JWT.Secret)
+	}
+
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	w := NewWatcher(cfg, lc, logger.NewNop())
+	if w == nil {
+		t.Fatal("NewWatcher returned nil for config with secret references")
+	}
+	var rotated []string
+	w.OnChange("JWT.SECRET", func(value string) { rotated = append(rotated, value) })
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	// 值未变化时不通知
+	w.Refresh(ctx)
+	if len(rotated) != 0 {
+		t.Fatalf("OnChange called without rotation: %v", rotated)
+	}
+
+	stub.set("second-secret-0123456789abcdefghijk", false)
+	w.Refresh(ctx)
+	if got := w.Get("JWT.SECRET", cfg.JWT.Secret); got != "second-secret-0123456789abcdefghijk" {
+		t.Fatalf("Get() = %q, want rotated secret", got)
+	}
+	if len(rotated) != 1 || rotated[0] != "second-secret-0123456789abcdefghijk" {
+		t.Fatalf("OnChange values = %v, want the rotated secret once", rotated)
+	}
+
+	// 刷新失败时保留旧值
+	stub.set("", true)
+	w.Refresh(ctx)
+	if got := w.Get("JWT.SECRET", cfg.JWT.Secret); got != "second-secret-0123456789abcdefghijk" {
+		t.Fatalf("Get() after failed refresh = %q, want previous value", got)
+	}
+	if len(rotated) != 1 {
+		t.Fatalf("OnChange called on failed refresh: %v", rotated)
+	}
+}
+
+func TestWatcherNil(t *testing.T) {
+	cfg := &config.Config{}
+	lc, _ := app.NewLifecycle(cfg, logger.NewNop())
+	w := NewWatcher(cfg, lc, logger.NewNop())
+	if w != nil {
+		t.Fatal("NewWatcher without references should return nil")
+	}
+	if got := w.Get("JWT.SECRET", "fallback"); got != "fallback" {
+		t.Fatalf("nil Watcher Get() = %q, want fallback", got)
+	}
+	w.OnChange("JWT.SECRET", func(string) {})
+	w.Refresh(context.Background())
+}
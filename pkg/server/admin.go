@@ -0,0 +1,136 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/http/pprof"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"go.uber.org/zap"
+)
+
+// configureAdminServer sets up the internal listener hosting ops endpoints,
+// keeping health, metrics, pprof and config dumps off the public surface
+func (s *Server) configureAdminServer() {
+	cfg := &s.cfg.Server.Admin
+	if !cfg.Enabled {
+		return
+	}
+
+	engine := gin.New()
+	engine.Use(gin.Recovery())
+
+	engine.GET("/healthz", s.liveness)
+	engine.GET("/readyz", s.readiness)
+
+	if s.metrics != nil {
+		engine.GET("/metrics", gin.WrapH(s.metrics.Handler()))
+	}
+
+	if cfg.Pprof {
+		debug := engine.Group("/debug/pprof")
+		{
+			debug.GET("/", gin.WrapF(pprof.Index))
+			debug.GET("/cmdline", gin.WrapF(pprof.Cmdline))
+			debug.GET("/profile", gin.WrapF(pprof.Profile))
+			debug.POST("/symbol", gin.WrapF(pprof.Symbol))
+			debug.GET("/symbol", gin.WrapF(pprof.Symbol))
+			debug.GET("/trace", gin.WrapF(pprof.Trace))
+			debug.GET("/:profile", func(c *gin.Context) {
+				pprof.Handler(c.Param("profile")).ServeHTTP(c.Writer, c.Request)
+			})
+		}
+	}
+
+	if cfg.ConfigDump {
+		engine.GET("/config", s.configDump)
+	}
+
+	// zap.AtomicLevel serves GET (current level) and PUT {"level":"debug"}
+	level := s.log.Level()
+	engine.GET("/log/level", gin.WrapH(level))
+	engine.PUT("/log/level", gin.WrapH(level))
+
+	s.admin = &http.Server{
+		Addr:              net.JoinHostPort(cfg.Host, strconv.Itoa(cfg.Port)),
+		Handler:           engine,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+
+	s.lc.Append(app.Hook{
+		Name:    "admin",
+		OnStart: s.startAdmin,
+		OnStop:  s.stopAdmin,
+	})
+}
+
+// startAdmin binds the admin listener
+func (s *Server) startAdmin(context.Context) error {
+	ln, err := net.Listen("tcp", s.admin.Addr)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("Admin server starting", zap.String("address", s.admin.Addr))
+	go func() {
+		if err := s.admin.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.lc.Fail(fmt.Errorf("admin server: %w", err))
+		}
+	}()
+	return nil
+}
+
+// stopAdmin shuts down the admin listener
+func (s *Server) stopAdmin(ctx context.Context) error {
+	if err := s.admin.Shutdown(ctx); err != nil {
+		return fmt.Errorf("admin server shutdown error: %w", err)
+	}
+	return nil
+}
+
+// liveness reports that the process is up; it never checks dependencies
+func (s *Server) liveness(c *gin.Context) {
+	c.JSON(http.StatusOK, gin.H{"status": "alive"})
+}
+
+// readiness reports whether the instance should receive traffic
+func (s *Server) readiness(c *gin.Context) {
+	if !s.lc.Ready() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "not ready"})
+		return
+	}
+
+	checks := gin.H{}
+	status, result := http.StatusOK, "ready"
+
+	if s.db != nil {
+		if err := s.db.WithContext(c.Request.Context()).Exec("SELECT 1").Error; err != nil {
+			checks["database"] = err.Error()
+			status, result = http.StatusServiceUnavailable, "not ready"
+		} else {
+			checks["database"] = "ok"
+		}
+	}
+
+	if s.redis != nil {
+		if s.redis.HealthCheck() {
+			checks["redis"] = "ok"
+		} else {
+			checks["redis"] = "unreachable"
+			status, result = http.StatusServiceUnavailable, "not ready"
+		}
+	}
+
+	c.JSON(status, gin.H{"status": result, "checks": checks})
+}
+
+// configDump returns the effective configuration with secrets redacted
+func (s *Server) configDump(c *gin.Context) {
+	c.JSON(http.StatusOK, s.cfg.Redacted())
+}
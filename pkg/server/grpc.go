@@ -0,0 +1,220 @@
+package server
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"runtime/debug"
+	"strconv"
+	"time"
+
+	"github.com/mjcode-max/TurboGin/pkg/app"
+	"github.com/soheilhy/cmux"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// configureGRPCServer builds the optional gRPC server. Services are attached by
+// registerGRPC and by modules implementing module.GRPCRegistrar; auth and rate
+// limiting reuse the HTTP middleware instances.
+func (s *Server) configureGRPCServer(registerGRPC func(*grpc.Server)) {
+	cfg := &s.cfg.Server.GRPC
+	if !cfg.Enabled {
+		return
+	}
+
+	opts := []grpc.ServerOption{
+		grpc.MaxRecvMsgSize(cfg.MaxRecvMsgSize),
+		grpc.ChainUnaryInterceptor(
+			s.grpcRecoveryUnary,
+			s.grpcLoggingUnary,
+			s.middlewares.rateLimit.UnaryInterceptor(),
+			s.middlewares.auth.UnaryInterceptor(),
+		),
+		grpc.ChainStreamInterceptor(
+			s.grpcRecoveryStream,
+			s.grpcLoggingStream,
+			s.middlewares.rateLimit.StreamInterceptor(),
+			s.middlewares.auth.StreamInterceptor(),
+		),
+	}
+
+	// Handshakes defer to the HTTPS config, which is only built at start and
+	// follows certificate reloads
+	if s.cfg.Server.TLS.Enabled {
+		opts = append(opts, grpc.Creds(credentials.NewTLS(&tls.Config{
+			GetConfigForClient: func(hello *tls.ClientHelloInfo) (*tls.Config, error) {
+				return s.http.TLSConfig.GetConfigForClient(hello)
+			},
+		})))
+	}
+
+	s.grpc = grpc.NewServer(opts...)
+
+	s.grpcHealth = health.NewServer()
+	healthpb.RegisterHealthServer(s.grpc, s.grpcHealth)
+	if cfg.Reflection {
+		reflection.Register(s.grpc)
+	}
+	if registerGRPC != nil {
+		registerGRPC(s.grpc)
+	}
+	s.modules.RegisterGRPC(s.grpc)
+
+	// A shared port is served from the HTTP listener via cmux in start()
+	if cfg.Shared(s.cfg.Server.Port) {
+		return
+	}
+
+	// Registered after the HTTP hook so the TLS config is available at start
+	s.lc.Append(app.Hook{
+		Name:    "grpc",
+		OnStart: s.startGRPC,
+		OnStop:  s.stopGRPC,
+	})
+}
+
+// GRPC returns the underlying gRPC server, nil when disabled
+func (s *Server) GRPC() *grpc.Server {
+	return s.grpc
+}
+
+// startGRPC binds the dedicated gRPC listener
+func (s *Server) startGRPC(context.Context) error {
+	addr := net.JoinHostPort(s.cfg.Server.Host, strconv.Itoa(s.cfg.Server.GRPC.Port))
+	ln, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+
+	s.log.Info("gRPC server starting",
+		zap.String("address", addr),
+		zap.Bool("tls", s.cfg.Server.TLS.Enabled))
+	s.serveGRPC(ln)
+	return nil
+}
+
+// serveGRPC serves on ln in the background and marks all services as serving
+func (s *Server) serveGRPC(ln net.Listener) {
+	s.grpcHealth.Resume()
+	go func() {
+		if err := s.grpc.Serve(ln); err != nil && !errors.Is(err, grpc.ErrServerStopped) && !isClosedErr(err) {
+			s.lc.Fail(fmt.Errorf("grpc server: %w", err))
+		}
+	}()
+}
+
+// stopGRPC reports NOT_SERVING, then waits for in-flight RPCs until ctx expires
+func (s *Server) stopGRPC(ctx context.Context) error {
+	s.grpcHealth.Shutdown()
+
+	done := make(chan struct{})
+	go func() {
+		s.grpc.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		s.grpc.Stop()
+		<-done
+		return fmt.Errorf("grpc server shutdown error: %w", ctx.Err())
+	}
+}
+
+// splitGRPC multiplexes ln, serving gRPC on the matched connections and
+// returning the listener that carries everything else to the HTTP server
+func (s *Server) splitGRPC(ln net.Listener) net.Listener {
+	s.mux = cmux.New(ln)
+	s.muxRoot = ln
+
+	grpcLn := s.mux.MatchWithWriters(cmux.HTTP2MatchHeaderFieldSendSettings("content-type", "application/grpc"))
+	httpLn := s.mux.Match(cmux.Any())
+
+	s.serveGRPC(grpcLn)
+	go func() {
+		if err := s.mux.Serve(); err != nil && !isClosedErr(err) {
+			s.lc.Fail(fmt.Errorf("grpc mux: %w", err))
+		}
+	}()
+	return httpLn
+}
+
+// closeMux stops the shared-port multiplexer
+func (s *Server) closeMux() {
+	if s.mux == nil {
+		return
+	}
+	s.mux.Close()
+	_ = s.muxRoot.Close()
+}
+
+// -------------------------- Interceptors --------------------------
+
+func (s *Server) grpcRecoveryUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (resp interface{}, err error) {
+	defer s.recoverGRPC(info.FullMethod, &err)
+	return handler(ctx, req)
+}
+
+func (s *Server) grpcRecoveryStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) (err error) {
+	defer s.recoverGRPC(info.FullMethod, &err)
+	return handler(srv, ss)
+}
+
+// recoverGRPC converts a handler panic into codes.Internal
+func (s *Server) recoverGRPC(method string, err *error) {
+	if r := recover(); r != nil {
+		s.log.Error("gRPC handler panic",
+			zap.String("method", method),
+			zap.Any("panic", r),
+			zap.ByteString("stack", debug.Stack()))
+		*err = status.Error(codes.Internal, "internal server error")
+	}
+}
+
+func (s *Server) grpcLoggingUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	s.logRPC(info.FullMethod, start, err)
+	return resp, err
+}
+
+func (s *Server) grpcLoggingStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, ss)
+	s.logRPC(info.FullMethod, start, err)
+	return err
+}
+
+func (s *Server) logRPC(method string, start time.Time, err error) {
+	code := status.Code(err)
+	fields := []zap.Field{
+		zap.String("method", method),
+		zap.String("code", code.String()),
+		zap.Duration("latency", time.Since(start)),
+	}
+	switch code {
+	case codes.OK:
+		s.log.Info("gRPC request", fields...)
+	case codes.Internal, codes.Unknown, codes.DataLoss, codes.Unavailable:
+		s.log.Error("gRPC request", append(fields, zap.Error(err))...)
+	default:
+		s.log.Warn("gRPC request", append(fields, zap.Error(err))...)
+	}
+}
+
+// -------------------------- Helpers --------------------------
+
+func isClosedErr(err error) bool {
+	return errors.Is(err, net.ErrClosed) || errors.Is(err, cmux.ErrServerClosed) || errors.Is(err, cmux.ErrListenerClosed)
+}
@@ -2,20 +2,25 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"github.com/mjcode-max/TurboGin/config"
 	"github.com/mjcode-max/TurboGin/internal/controller"
+	"github.com/mjcode-max/TurboGin/pkg/app"
 	"github.com/mjcode-max/TurboGin/pkg/logger"
 	"github.com/mjcode-max/TurboGin/pkg/middleware"
+	"github.com/mjcode-max/TurboGin/pkg/module"
+	"github.com/mjcode-max/TurboGin/pkg/redis"
+	"github.com/mjcode-max/TurboGin/pkg/route"
+	"net"
 	"net/http"
-	"os"
-	"os/signal"
 	"strconv"
-	"syscall"
-	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/soheilhy/cmux"
 	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 	"gorm.io/gorm"
 )
 
@@ -23,9 +28,12 @@ import (
 type Server struct {
 	engine *gin.Engine
 	http   *http.Server
+	admin  *http.Server
 	db     *gorm.DB
+	redis  *redis.Client
 	cfg    *config.Config
 	log    *logger.Logger
+	lc     *app.Lifecycle
 
 	// Middleware
 	middlewares struct {
@@ -34,28 +42,50 @@ type Server struct {
 		rateLimit *middleware.RateLimiter
 		allowed   *middleware.IPAccess
 	}
+	metrics *middleware.Metrics
+
+	// gRPC
+	grpc       *grpc.Server
+	grpcHealth *health.Server
+	mux        cmux.CMux
+	muxRoot    net.Listener
+
+	// TLS
+	certs    *certReloader
+	redirect *http.Server
 
 	// Controllers
 	controllers *controller.Container
+	modules     *module.Manager
+	routes      *route.Table
 }
 
 // New creates a new Server instance (dependency injection entry point)
 func New(
 	cfg *config.Config,
+	lc *app.Lifecycle,
 	db *gorm.DB,
+	rdb *redis.Client,
 	log *logger.Logger,
 	auth *middleware.Auth,
 	cors *middleware.CORS,
 	rateLimit *middleware.RateLimiter,
 	allowed *middleware.IPAccess,
+	metrics *middleware.Metrics,
 	controllers *controller.Container,
-	registerRoutes func(*gin.Engine),
-) *Server {
+	modules *module.Manager,
+	registerRoutes func(*route.Router),
+	registerGRPC func(*grpc.Server),
+) (*Server, error) {
 	s := &Server{
 		db:          db,
+		redis:       rdb,
+		metrics:     metrics,
 		cfg:         cfg,
 		log:         log,
+		lc:          lc,
 		controllers: controllers,
+		modules:     modules,
 	}
 
 	s.middlewares.auth = auth
@@ -63,19 +93,50 @@ func New(
 	s.middlewares.rateLimit = rateLimit
 	s.middlewares.allowed = allowed
 
-	s.initializeEngine(registerRoutes)
+	// With JWT disabled, mounting fails if any route requires authentication
+	// instead of serving it unauthenticated
+	if err := s.initializeEngine(registerRoutes); err != nil {
+		return nil, err
+	}
 	s.configureHTTPServer()
 
-	return s
+	// Registered before the public listener so it stops after it and keeps
+	// serving readiness/metrics while public traffic drains
+	s.configureAdminServer()
+
+	lc.Append(app.Hook{
+		Name:    "http",
+		OnStart: s.start,
+		OnStop:  s.stop,
+	})
+
+	s.configureGRPCServer(registerGRPC)
+
+	return s, nil
+}
+
+// Handler returns the public HTTP handler, e.g. for serving it from httptest
+func (s *Server) Handler() http.Handler {
+	return s.engine
+}
+
+// Routes returns the route table with per-route metadata (auth, permissions, rate limit policy, docs)
+func (s *Server) Routes() *route.Table {
+	return s.routes
 }
 
 // initializeEngine sets up the Gin engine with middleware and routes
-func (s *Server) initializeEngine(registerRoutes func(*gin.Engine)) {
+func (s *Server) initializeEngine(registerRoutes func(*route.Router)) error {
 	setGinMode(s.cfg.Env)
 	s.engine = gin.Default()
 
+	s.engine.Use(middleware.RequestID())
 	s.engine.Use(s.middlewares.allowed.Middleware())
 
+	if s.metrics != nil {
+		s.engine.Use(s.metrics.Middleware())
+	}
+
 	// Apply middleware
 	if s.cfg.Middleware.CORS.Enabled {
 		s.engine.Use(s.middlewares.cors.Middleware())
@@ -88,11 +149,33 @@ func (s *Server) initializeEngine(registerRoutes func(*gin.Engine)) {
 	// Configure JSON prefix
 	s.engine.SecureJsonPrefix("api")
 
-	// Register routes
-	registerRoutes(s.engine)
+	// Register routes, then the routes of every enabled module; the per-route
+	// middleware chains are built from their metadata when mounting
+	r := route.New(s.middlewares.auth, s.middlewares.rateLimit)
+	registerRoutes(r)
+	s.modules.RegisterRoutes(r)
+	if err := r.Mount(s.engine); err != nil {
+		return err
+	}
+	s.routes = r.Table()
+
+	if s.cfg.Server.EnableSwagger {
+		s.engine.GET("/openapi.json", s.openAPI)
+	}
+
+	// Health check stays on the public engine only when no admin listener is configured
+	if !s.cfg.Server.Admin.Enabled {
+		s.engine.GET("/health", s.healthCheck)
+	}
+	return nil
+}
 
-	// Register health check endpoint
-	s.engine.GET("/health", s.healthCheck)
+// openAPI serves the OpenAPI document generated from the route table
+func (s *Server) openAPI(c *gin.Context) {
+	c.JSON(http.StatusOK, s.routes.OpenAPI(route.Info{
+		Title:   "TurboGin API",
+		Version: s.cfg.Version,
+	}))
 }
 
 // configureHTTPServer sets up the HTTP server configuration
@@ -104,70 +187,160 @@ func (s *Server) configureHTTPServer() {
 		WriteTimeout:   s.cfg.Server.WriteTimeout,
 		MaxHeaderBytes: 1 << 20, // 1MB
 	}
+
+	// Allow prior-knowledge HTTP/2 over plain TCP for internal callers
+	if s.cfg.Server.H2C && !s.cfg.Server.TLS.Enabled {
+		var protocols http.Protocols
+		protocols.SetHTTP1(true)
+		protocols.SetUnencryptedHTTP2(true)
+		s.http.Protocols = &protocols
+	}
+
+	if s.cfg.Server.TLS.Enabled && s.cfg.Server.TLS.RedirectHTTP {
+		s.redirect = newRedirectServer(s.cfg.Server.Host, s.cfg.Server.TLS.RedirectPort, s.cfg.Server.Port)
+	}
 }
 
-// Run starts the server with graceful shutdown
-func (s *Server) Run() error {
-	// Start HTTP server in a goroutine
-	go func() {
-		s.log.Info("Server starting",
-			zap.String("address", s.http.Addr),
-			zap.String("env", s.cfg.Env),
-		)
+// start binds the listener synchronously so that address errors fail startup,
+// then serves in the background and reports unexpected failures to the lifecycle
+func (s *Server) start(context.Context) error {
+	tlsCfg := &s.cfg.Server.TLS
+	if tlsCfg.Enabled {
+		if err := s.setupTLS(tlsCfg); err != nil {
+			return err
+		}
+	}
+
+	ln, err := net.Listen("tcp", s.http.Addr)
+	if err != nil {
+		_ = s.closeCerts()
+		return err
+	}
+
+	grpcShared := s.grpc != nil && s.cfg.Server.GRPC.Shared(s.cfg.Server.Port)
+	if grpcShared {
+		ln = s.splitGRPC(ln)
+	}
+
+	s.log.Info("Server starting",
+		zap.String("address", s.http.Addr),
+		zap.String("env", s.cfg.Env),
+		zap.Bool("tls", tlsCfg.Enabled),
+		zap.Bool("h2c", s.cfg.Server.H2C && !tlsCfg.Enabled),
+		zap.Bool("grpc", grpcShared),
+	)
 
-		if err := s.http.ListenAndServe(); err != nil && err != http.ErrServerClosed {
-			s.log.Fatal("Server start failed", zap.Error(err))
+	go func() {
+		var err error
+		if tlsCfg.Enabled {
+			// Certificates come from TLSConfig; ServeTLS also enables HTTP/2 via ALPN
+			err = s.http.ServeTLS(ln, "", "")
+		} else {
+			err = s.http.Serve(ln)
+		}
+		if err != nil && !errors.Is(err, http.ErrServerClosed) && !isClosedErr(err) {
+			s.lc.Fail(fmt.Errorf("http server: %w", err))
 		}
 	}()
 
-	// Wait for interrupt signal
-	quit := make(chan os.Signal, 1)
-	signal.Notify(quit, syscall.SIGINT, syscall.SIGTERM)
-	<-quit
+	if s.redirect != nil {
+		if err := s.startRedirect(); err != nil {
+			_ = s.http.Close()
+			_ = s.closeCerts()
+			return err
+		}
+	}
+	return nil
+}
+
+// setupTLS loads certificates and optionally starts watching them for changes
+func (s *Server) setupTLS(cfg *config.TLSConfig) error {
+	certs, err := newCertReloader(cfg, s.log)
+	if err != nil {
+		return err
+	}
+	tlsConfig, err := certs.tlsConfig()
+	if err != nil {
+		return err
+	}
+	if cfg.WatchCerts {
+		if err := certs.watch(); err != nil {
+			return err
+		}
+	}
 
-	// Begin graceful shutdown
-	return s.shutdown()
+	s.certs = certs
+	s.http.TLSConfig = tlsConfig
+	return nil
 }
 
-// shutdown performs graceful server shutdown
-func (s *Server) shutdown() error {
-	s.log.Info("Shutting down server...")
+// startRedirect serves the HTTP to HTTPS redirect listener
+func (s *Server) startRedirect() error {
+	ln, err := net.Listen("tcp", s.redirect.Addr)
+	if err != nil {
+		return err
+	}
 
-	// Create shutdown context with timeout
-	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-	defer cancel()
+	s.log.Info("HTTPS redirect listener starting", zap.String("address", s.redirect.Addr))
+	go func() {
+		if err := s.redirect.Serve(ln); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			s.lc.Fail(fmt.Errorf("redirect server: %w", err))
+		}
+	}()
+	return nil
+}
 
-	// Shutdown HTTP server
+// closeCerts stops the certificate watcher if running
+func (s *Server) closeCerts() error {
+	if s.certs == nil {
+		return nil
+	}
+	return s.certs.close()
+}
+
+// stop gracefully shuts down the HTTP server, waiting for in-flight requests
+func (s *Server) stop(ctx context.Context) error {
+	s.log.Info("Shutting down server...")
+	if s.redirect != nil {
+		if err := s.redirect.Shutdown(ctx); err != nil {
+			return fmt.Errorf("redirect server shutdown error: %w", err)
+		}
+	}
+	// RPCs sharing the port finish before the multiplexer is torn down
+	if s.mux != nil {
+		if err := s.stopGRPC(ctx); err != nil {
+			return err
+		}
+	}
 	if err := s.http.Shutdown(ctx); err != nil {
 		return fmt.Errorf("server shutdown error: %w", err)
 	}
-
-	// Clean up other resources
-	s.cleanup()
-	return nil
+	s.closeMux()
+	return s.closeCerts()
 }
 
 // healthCheck handles health check requests
 func (s *Server) healthCheck(c *gin.Context) {
-	if err := s.db.Exec("SELECT 1").Error; err != nil {
-		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unhealthy"})
+	// Report unavailable while draining so load balancers stop routing traffic
+	if !s.lc.Ready() {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"status": "draining"})
 		return
 	}
 
+	// Database is optional; only check it when DATABASE.ENABLED
+	if s.db != nil {
+		if err := s.db.Exec("SELECT 1").Error; err != nil {
+			c.JSON(http.StatusServiceUnavailable, gin.H{"status": "unhealthy"})
+			return
+		}
+	}
+
 	c.JSON(http.StatusOK, gin.H{
 		"status":  "healthy",
 		"version": s.cfg.Version,
 	})
 }
 
-// cleanup releases server resources
-func (s *Server) cleanup() {
-	if sqlDB, err := s.db.DB(); err == nil {
-		_ = sqlDB.Close()
-	}
-	s.log.Info("Server resources released")
-}
-
 // -------------------------- Helper Methods --------------------------
 
 // setGinMode sets Gin mode based on environment
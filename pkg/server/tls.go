@@ -0,0 +1,235 @@
+package server
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/mjcode-max/TurboGin/config"
+	"github.com/mjcode-max/TurboGin/pkg/logger"
+	"go.uber.org/zap"
+)
+
+var tlsVersions = map[string]uint16{
+	"1.0": tls.VersionTLS10,
+	"1.1": tls.VersionTLS11,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify_if_given":    tls.VerifyClientCertIfGiven,
+	"require_and_verify": tls.RequireAndVerifyClientCert,
+}
+
+// certReloader serves the current certificate and client CA pool,
+// reloading both from disk whenever the watched files change
+type certReloader struct {
+	cfg *config.TLSConfig
+	log *logger.Logger
+
+	mu       sync.RWMutex
+	cert     *tls.Certificate
+	clientCA *x509.CertPool
+
+	watcher *fsnotify.Watcher
+	done    chan struct{}
+}
+
+// newCertReloader loads the initial certificate; failures here abort startup
+func newCertReloader(cfg *config.TLSConfig, log *logger.Logger) (*certReloader, error) {
+	r := &certReloader{cfg: cfg, log: log}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// reload reads the certificate pair and client CA bundle from disk
+func (r *certReloader) reload() error {
+	cert, err := tls.LoadX509KeyPair(r.cfg.CertFile, r.cfg.KeyFile)
+	if err != nil {
+		return fmt.Errorf("load TLS certificate: %w", err)
+	}
+
+	var pool *x509.CertPool
+	if r.cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(r.cfg.ClientCAFile)
+		if err != nil {
+			return fmt.Errorf("read client CA file: %w", err)
+		}
+		pool = x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return fmt.Errorf("no valid certificates in client CA file: %s", r.cfg.ClientCAFile)
+		}
+	}
+
+	r.mu.Lock()
+	r.cert = &cert
+	r.clientCA = pool
+	r.mu.Unlock()
+	return nil
+}
+
+// tlsConfig builds a config whose certificate and client CAs are resolved per handshake
+func (r *certReloader) tlsConfig() (*tls.Config, error) {
+	base, err := baseTLSConfig(r.cfg)
+	if err != nil {
+		return nil, err
+	}
+
+	base.GetConfigForClient = func(*tls.ClientHelloInfo) (*tls.Config, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+
+		c := base.Clone()
+		c.GetConfigForClient = nil
+		c.Certificates = []tls.Certificate{*r.cert}
+		c.ClientCAs = r.clientCA
+		return c, nil
+	}
+	// Satisfies ServeTLS, which requires a certificate source when no files are passed
+	base.GetCertificate = func(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+		r.mu.RLock()
+		defer r.mu.RUnlock()
+		return r.cert, nil
+	}
+	return base, nil
+}
+
+// watch reloads certificates on file changes. Parent directories are watched
+// so that atomic symlink swaps (e.g. Kubernetes secret mounts) are detected.
+func (r *certReloader) watch() error {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("create certificate watcher: %w", err)
+	}
+
+	dirs := map[string]struct{}{}
+	for _, f := range []string{r.cfg.CertFile, r.cfg.KeyFile, r.cfg.ClientCAFile} {
+		if f != "" {
+			dirs[filepath.Dir(f)] = struct{}{}
+		}
+	}
+	for dir := range dirs {
+		if err := watcher.Add(dir); err != nil {
+			_ = watcher.Close()
+			return fmt.Errorf("watch %s: %w", dir, err)
+		}
+	}
+
+	r.watcher = watcher
+	r.done = make(chan struct{})
+	go r.loop()
+	return nil
+}
+
+func (r *certReloader) loop() {
+	defer close(r.done)
+
+	// Debounce bursts of events emitted while files are being replaced
+	var timer *time.Timer
+	for {
+		select {
+		case event, ok := <-r.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create|fsnotify.Rename|fsnotify.Remove) == 0 {
+				continue
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			timer = time.AfterFunc(500*time.Millisecond, func() {
+				if err := r.reload(); err != nil {
+					r.log.Error("TLS certificate reload failed, keeping previous certificate", zap.Error(err))
+					return
+				}
+				r.log.Info("TLS certificate reloaded", zap.String("cert", r.cfg.CertFile))
+			})
+		case err, ok := <-r.watcher.Errors:
+			if !ok {
+				return
+			}
+			r.log.Warn("TLS certificate watcher error", zap.Error(err))
+		}
+	}
+}
+
+// close stops the file watcher
+func (r *certReloader) close() error {
+	if r.watcher == nil {
+		return nil
+	}
+	err := r.watcher.Close()
+	<-r.done
+	return err
+}
+
+// baseTLSConfig translates static settings (versions, cipher suites, client auth)
+func baseTLSConfig(cfg *config.TLSConfig) (*tls.Config, error) {
+	minVersion, ok := tlsVersions[cfg.MinVersion]
+	if !ok {
+		return nil, fmt.Errorf("unsupported TLS min version: %s", cfg.MinVersion)
+	}
+
+	c := &tls.Config{
+		MinVersion: minVersion,
+		// Advertised explicitly because per-client configs bypass ServeTLS's ALPN setup
+		NextProtos: []string{"h2", "http/1.1"},
+	}
+
+	if len(cfg.CipherSuites) > 0 {
+		known := map[string]uint16{}
+		for _, suite := range tls.CipherSuites() {
+			known[suite.Name] = suite.ID
+		}
+		for _, name := range cfg.CipherSuites {
+			id, ok := known[name]
+			if !ok {
+				return nil, fmt.Errorf("unsupported or insecure cipher suite: %s", name)
+			}
+			c.CipherSuites = append(c.CipherSuites, id)
+		}
+	}
+
+	if cfg.ClientCAFile != "" {
+		auth, ok := clientAuthTypes[cfg.ClientAuth]
+		if !ok {
+			return nil, fmt.Errorf("unsupported client auth type: %s", cfg.ClientAuth)
+		}
+		c.ClientAuth = auth
+	}
+
+	return c, nil
+}
+
+// newRedirectServer redirects plain HTTP requests to the HTTPS listener
+func newRedirectServer(host string, redirectPort, httpsPort int) *http.Server {
+	return &http.Server{
+		Addr:              net.JoinHostPort(host, strconv.Itoa(redirectPort)),
+		ReadHeaderTimeout: 5 * time.Second,
+		Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			target := r.Host
+			if h, _, err := net.SplitHostPort(r.Host); err == nil {
+				target = h
+			}
+			if httpsPort != 443 {
+				target = net.JoinHostPort(target, strconv.Itoa(httpsPort))
+			}
+			http.Redirect(w, r, "https://"+target+r.URL.RequestURI(), http.StatusMovedPermanently)
+		}),
+	}
+}